@@ -0,0 +1,36 @@
+package signals
+
+import (
+	"context"
+	"os"
+)
+
+// Actor returns an execute and interrupt pair, as expected by
+// github.com/oklog/run and similar actor runners, that handles the specified
+// signals like Context:
+//
+//	var g run.Group
+//	g.Add(signals.Actor(syscall.SIGINT, syscall.SIGTERM))
+//
+// Execute blocks until one of the signals is received, and then returns the
+// Canceled holding it, so that the error of the group tells which signal ended
+// it; or until interrupt is called, and then returns nil. The signals are
+// registered by Actor, so that none is missed before execute is called, and
+// unregistered once execute returns.
+//
+// If no signals are provided, all incoming signals will be relayed.
+func Actor(signals ...os.Signal) (execute func() error, interrupt func(error)) {
+	ctx, stop := Context(context.Background(), signals...)
+	execute = func() error {
+		<-ctx.Done()
+		stop()
+		if c, ok := AsCanceled(context.Cause(ctx)); ok {
+			return c
+		}
+		return nil
+	}
+	interrupt = func(error) {
+		stop()
+	}
+	return execute, interrupt
+}
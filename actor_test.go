@@ -0,0 +1,39 @@
+package signals_test
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestActor(t *testing.T) {
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		execute, interrupt := signals.Actor(syscall.SIGINT, syscall.SIGTERM)
+		src.AssertSubscribed(t, syscall.SIGTERM)
+		src.Send(syscall.SIGTERM)
+
+		err := execute()
+		if c, ok := signals.AsCanceled(err); !ok || c.Signal != syscall.SIGTERM {
+			t.Errorf("Expected %v, got %v", signals.NewCanceled(syscall.SIGTERM), err)
+		}
+		interrupt(err)
+		src.AssertNotSubscribed(t, syscall.SIGTERM)
+	})
+
+	t.Run("Interrupted", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		execute, interrupt := signals.Actor(syscall.SIGINT)
+		done := make(chan error, 1)
+		go func() { done <- execute() }()
+
+		interrupt(errors.New("other actor"))
+		if err := <-done; err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		src.AssertNotSubscribed(t, syscall.SIGINT)
+	})
+}
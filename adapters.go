@@ -0,0 +1,65 @@
+package signals
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Channel returns a channel that is closed when ctx is done, such as a context
+// created by Context, for the APIs that take a stop channel of type
+// <-chan struct{} instead of a context.
+func Channel(ctx context.Context) <-chan struct{} {
+	return ctx.Done()
+}
+
+// Closer arranges to close c once ctx is done, so that an API that does not
+// accept a context, such as a net.Listener blocked in Accept, is interrupted
+// by the signal-driven shutdown. The error of Close is ignored.
+//
+// Calling the returned stop function stops the association of c with ctx.
+// It returns true if the call stopped c from being closed, and false if c has
+// already been closed or stop was already called.
+func Closer(ctx context.Context, c io.Closer) (stop func() bool) {
+	return onDone(ctx, func() { c.Close() })
+}
+
+// StopTimer arranges to stop t once ctx is done, as described for Closer.
+func StopTimer(ctx context.Context, t *time.Timer) (stop func() bool) {
+	return onDone(ctx, func() { t.Stop() })
+}
+
+// StopTicker arranges to stop t once ctx is done, as described for Closer.
+// Note that stopping a ticker does not close its channel: a receiver of t.C
+// should also receive from Channel(ctx).
+func StopTicker(ctx context.Context, t *time.Ticker) (stop func() bool) {
+	return onDone(ctx, t.Stop)
+}
+
+// onDone calls f in its own goroutine once ctx is done, unless stop is called
+// first, like context.AfterFunc.
+func onDone(ctx context.Context, f func()) (stop func() bool) {
+	var once sync.Once
+	claim := func() (claimed bool) {
+		once.Do(func() { claimed = true })
+		return claimed
+	}
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			if claim() {
+				f()
+			}
+		case <-stopped:
+		}
+	}()
+	return func() bool {
+		if claim() {
+			close(stopped)
+			return true
+		}
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+package signals_test
+
+import (
+	"context"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestAdapters(t *testing.T) {
+	t.Run("Channel", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		src.Send(syscall.SIGTERM)
+		select {
+		case <-signals.Channel(ctx):
+		case <-time.After(signalstest.AssertTimeout):
+			t.Fatal("Expected the channel to be closed")
+		}
+	})
+
+	t.Run("Closer", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Skip(err)
+		}
+		signals.Closer(ctx, ln)
+
+		accepted := make(chan error, 1)
+		go func() {
+			_, err := ln.Accept()
+			accepted <- err
+		}()
+		src.Send(syscall.SIGTERM)
+		select {
+		case err := <-accepted:
+			if err == nil {
+				t.Error("Expected Accept to fail once the listener is closed")
+			}
+		case <-time.After(signalstest.AssertTimeout):
+			t.Fatal("Expected Accept to be interrupted")
+		}
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		timer := time.NewTimer(time.Hour)
+		defer timer.Stop()
+		stop := signals.StopTimer(ctx, timer)
+		if !stop() {
+			t.Error("Expected the first stop to return true")
+		}
+		if stop() {
+			t.Error("Expected the second stop to return false")
+		}
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		if !timer.Stop() {
+			t.Error("Expected the timer to be still active")
+		}
+	})
+
+	t.Run("StopTicker", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ticker := time.NewTicker(time.Millisecond)
+		signals.StopTicker(ctx, ticker)
+		cancel()
+		deadline := time.After(signalstest.AssertTimeout)
+		for {
+			select {
+			case <-ticker.C:
+				continue
+			case <-time.After(50 * time.Millisecond):
+			case <-deadline:
+				t.Fatal("Expected the ticker to be stopped")
+			}
+			break
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// AfterFunc arranges to call f in its own goroutine once sig is received.
+// It mirrors context.AfterFunc, keyed on a signal instead of a context.
+//
+// If ctx is done before sig is received, f is never called and the signal
+// registration is released.
+//
+// Calling the returned stop function stops the association of f with sig.
+// It returns true if the call stopped f from being run. If stop returns false,
+// either f has been started in its own goroutine, ctx is already done, or f
+// has already been stopped. The stop function does not wait for f to complete.
+func AfterFunc(ctx context.Context, sig os.Signal, f func()) (stop func() bool) {
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, sig)
+	var once sync.Once
+	claim := func() (claimed bool) {
+		once.Do(func() {
+			claimed = true
+			source.Stop(ch)
+		})
+		return claimed
+	}
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ch:
+			if claim() {
+				f()
+			}
+		case <-ctx.Done():
+			claim()
+		case <-stopped:
+		}
+	}()
+	return func() bool {
+		if claim() {
+			close(stopped)
+			return true
+		}
+		return false
+	}
+}
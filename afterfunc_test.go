@@ -0,0 +1,68 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestAfterFunc(t *testing.T) {
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		called := make(chan struct{})
+		stop := signals.AfterFunc(context.Background(), syscall.SIGUSR1, func() { close(called) })
+
+		src.Send(syscall.SIGUSR1)
+		select {
+		case <-called:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected f to be called")
+		}
+		if stop() {
+			t.Error("Expected stop to return false after f started")
+		}
+		src.AssertNotSubscribed(t, syscall.SIGUSR1)
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		stop := signals.AfterFunc(context.Background(), syscall.SIGUSR1, func() {
+			t.Error("Expected f not to be called")
+		})
+
+		if !stop() {
+			t.Error("Expected stop to return true")
+		}
+		if stop() {
+			t.Error("Expected the second stop to return false")
+		}
+		src.AssertNotSubscribed(t, syscall.SIGUSR1)
+		if n := src.Send(syscall.SIGUSR1); n != 0 {
+			t.Errorf("Expected no delivery, got %d", n)
+		}
+	})
+
+	t.Run("Context done", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		stop := signals.AfterFunc(ctx, syscall.SIGUSR1, func() {
+			t.Error("Expected f not to be called")
+		})
+
+		cancel()
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer waitCancel()
+		if err := src.WaitNotSubscribed(waitCtx, syscall.SIGUSR1); err != nil {
+			t.Errorf("Expected no subscriber of %v, got %v", syscall.SIGUSR1, err)
+		}
+		if stop() {
+			t.Error("Expected stop to return false after ctx is done")
+		}
+	})
+}
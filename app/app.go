@@ -0,0 +1,230 @@
+// Package app is a blueprint for signal-driven services, tying together the
+// pieces of package signals: the signal context, the startup and shutdown of
+// components, the health flip, the lifetime metrics and the shutdown log.
+//
+//	a := app.New(app.WithGracePeriod(20 * time.Second))
+//	a.OnStart("db", openDB, closeDB)
+//	a.OnShutdown("flush", flushMetrics)
+//	http.Handle("/readyz", a.ReadyHandler())
+//
+//	err := a.Run(func(ctx context.Context) error {
+//		return serve(ctx)
+//	})
+//
+// On SIGINT or SIGTERM, /readyz fails at once so that the load balancer stops
+// routing traffic, run returns, the shutdown hooks run, the components are
+// stopped in reverse order, and the shutdown is logged as
+// "app: stopped uptime=3h2m1s shutdown=1.5s signal="terminated"".
+//
+// An App uses the signal source and the clock of package signals, so its tests
+// can drive it with signalstest.NewFakeSource and signalstest.NewFakeClock.
+package app
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goaux/signals"
+)
+
+// App runs a service under a context canceled by signals.
+// It must be created with New.
+type App struct {
+	parent  context.Context
+	signals []os.Signal
+	options []signals.Option
+	logf    func(format string, args ...any)
+
+	lifecycle signals.Lifecycle
+	hooks     signals.Hooks
+	ready     atomic.Bool
+
+	mu      sync.Mutex
+	process *signals.Process
+	fresh   bool // whether each Run records its lifetime with a new Process
+	ran     bool // whether Run was called
+}
+
+// Option configures New.
+type Option func(*App)
+
+// WithContext specifies the parent of the context of Run.
+// The default is context.Background().
+func WithContext(ctx context.Context) Option {
+	return func(a *App) {
+		a.parent = ctx
+	}
+}
+
+// WithSignals specifies the signals that shut the App down.
+// The default is signals.Interrupt and signals.Terminate.
+func WithSignals(sigs ...os.Signal) Option {
+	return func(a *App) {
+		a.signals = append(a.signals, sigs...)
+	}
+}
+
+// WithGracePeriod specifies the grace period of the shutdown,
+// as by signals.WithGracePeriod.
+func WithGracePeriod(d time.Duration) Option {
+	return WithContextOptions(signals.WithGracePeriod(d))
+}
+
+// WithContextOptions specifies further options of the context of Run,
+// such as signals.WithEscalation or signals.WithSoftCancel.
+func WithContextOptions(options ...signals.Option) Option {
+	return func(a *App) {
+		a.options = append(a.options, options...)
+	}
+}
+
+// WithLogf specifies the function used instead of log.Printf to report the
+// startup and the shutdown.
+func WithLogf(logf func(format string, args ...any)) Option {
+	return func(a *App) {
+		a.logf = logf
+	}
+}
+
+// WithProcess specifies the Process recording the lifetime of the App, for
+// example signals.CurrentProcess(). By default, each Run records its lifetime
+// with a new Process.
+func WithProcess(p *signals.Process) Option {
+	return func(a *App) {
+		a.process = p
+	}
+}
+
+// New returns an App configured by options.
+func New(options ...Option) *App {
+	a := &App{
+		parent: context.Background(),
+		logf:   log.Printf,
+	}
+	for _, o := range options {
+		o(a)
+	}
+	if len(a.signals) == 0 {
+		a.signals = []os.Signal{signals.Interrupt, signals.Terminate}
+	}
+	if a.process == nil {
+		a.process, a.fresh = signals.NewProcess(), true
+	}
+	return a
+}
+
+// OnStart appends a component of the given name, started by start before run
+// is called and stopped by stop after it returns, in reverse order, as by
+// signals.Lifecycle. Either function may be nil.
+func (a *App) OnStart(name string, start, stop func(ctx context.Context) error) {
+	a.lifecycle.OnStart(name, start, stop)
+}
+
+// OnShutdown adds a shutdown hook, as by signals.Hooks.Add. The hooks run
+// after run returns and before the components are stopped.
+func (a *App) OnShutdown(name string, f func(ctx context.Context) error, options ...signals.HookOption) error {
+	return a.hooks.Add(name, f, options...)
+}
+
+// Ready reports whether the App is ready to serve: all its components are
+// started and its shutdown has not begun.
+func (a *App) Ready() bool {
+	return a.ready.Load()
+}
+
+// ReadyHandler returns a handler for readiness probes, which responds with
+// 200 OK while the App is Ready, and with 503 Service Unavailable otherwise.
+func (a *App) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.Ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok\n"))
+	})
+}
+
+// Process returns the Process recording the lifetime of the current or last
+// Run, or of the first one if Run was not called yet, for example to export
+// its uptime, or to record the shutdown with OnShutdownComplete.
+func (a *App) Process() *signals.Process {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.process
+}
+
+// Run starts the components with a context canceled when one of the signals
+// is received, then calls run with this context, as by
+// signals.Lifecycle.RunContext. Once all components are started, the App is
+// Ready until its shutdown begins: when a signal is received, or when run
+// returns.
+//
+// After run returns, Run runs the shutdown hooks, then stops the components
+// already started in reverse order, both with a detached context, as by
+// signals.Detach. If a component fails to start, or a signal is received
+// during startup, neither run nor the shutdown hooks are called. It finally
+// completes the shutdown of the Process and logs its Lifetime.
+//
+// Run returns the error of run, unless it is the cancellation of the context
+// by a signal, joined with a signals.HookErrors if a component or hook failed.
+// Run may be called again once it has returned.
+func (a *App) Run(run func(ctx context.Context) error) error {
+	a.mu.Lock()
+	if a.fresh && a.ran {
+		a.process = signals.NewProcess()
+	}
+	a.ran = true
+	process := a.process
+	a.mu.Unlock()
+	defer func() {
+		a.logf("app: stopped %v", process.CompleteShutdown())
+	}()
+
+	options := append([]signals.Option{signals.WithSignals(a.signals...)}, a.options...)
+	ctx, stop := signals.NewContext(a.parent, options...)
+	defer stop()
+
+	var once sync.Once
+	shutdown := func() {
+		once.Do(func() {
+			a.ready.Store(false)
+			process.BeginShutdown(ctx)
+			if sig, ok := signals.FromContext(ctx); ok {
+				a.logf("app: shutting down on %q", sig)
+			} else {
+				a.logf("app: shutting down")
+			}
+		})
+	}
+	defer shutdown()
+
+	return a.lifecycle.RunContext(ctx, func(ctx context.Context) error {
+		a.ready.Store(true)
+		a.logf("app: started")
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-signals.ShuttingDown(ctx):
+				shutdown()
+			case <-done:
+			}
+		}()
+		err := run(ctx)
+		close(done)
+		if _, ok := signals.FromContext(ctx); ok && ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+			err = nil
+		}
+		shutdown()
+
+		if errs := a.hooks.Run(signals.Detach(ctx)); errs != nil {
+			return errors.Join(err, errs)
+		}
+		return err
+	})
+}
@@ -0,0 +1,235 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/app"
+	"github.com/goaux/signals/signalstest"
+)
+
+// recorder records the calls made by an App and the lines it logs.
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+	logs  []string
+}
+
+func (r *recorder) record(call string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+func (r *recorder) hook(call string, err error) func(context.Context) error {
+	return func(context.Context) error {
+		r.record(call)
+		return err
+	}
+}
+
+func (r *recorder) logf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+func status(a *app.App) int {
+	w := httptest.NewRecorder()
+	a.ReadyHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	return w.Code
+}
+
+func TestApp(t *testing.T) {
+	t.Run("Signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clk := signalstest.NewFakeClock(t)
+		var r recorder
+		a := app.New(app.WithLogf(r.logf))
+		a.OnStart("a", r.hook("start a", nil), r.hook("stop a", nil))
+		a.OnStart("b", r.hook("start b", nil), r.hook("stop b", nil))
+		a.OnShutdown("flush", func(ctx context.Context) error {
+			r.record("flush")
+			if a.Ready() {
+				t.Error("Expected not ready during shutdown")
+			}
+			clk.Advance(2 * time.Second)
+			return nil
+		})
+
+		err := a.Run(func(ctx context.Context) error {
+			r.record("run")
+			if !a.Ready() {
+				t.Error("Expected ready")
+			}
+			if code := status(a); code != http.StatusOK {
+				t.Errorf("Expected %d, got %d", http.StatusOK, code)
+			}
+			src.Send(syscall.SIGTERM)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		if err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		want := []string{"start a", "start b", "run", "flush", "stop b", "stop a"}
+		if !reflect.DeepEqual(r.calls, want) {
+			t.Errorf("Expected %v, got %v", want, r.calls)
+		}
+		if code := status(a); code != http.StatusServiceUnavailable {
+			t.Errorf("Expected %d, got %d", http.StatusServiceUnavailable, code)
+		}
+		l := a.Process().Lifetime()
+		if l.Signal != syscall.SIGTERM {
+			t.Errorf("Expected %v, got %v", syscall.SIGTERM, l.Signal)
+		}
+		if d := l.ShutdownElapsed(); d != 2*time.Second {
+			t.Errorf("Expected 2s, got %v", d)
+		}
+		wantLogs := []string{
+			"app: started",
+			`app: shutting down on "terminated"`,
+			`app: stopped uptime=2s shutdown=2s signal="terminated"`,
+		}
+		if !reflect.DeepEqual(r.logs, wantLogs) {
+			t.Errorf("Expected %q, got %q", wantLogs, r.logs)
+		}
+	})
+
+	t.Run("Run returns", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		var r recorder
+		a := app.New(app.WithLogf(r.logf))
+		a.OnStart("a", r.hook("start a", nil), r.hook("stop a", nil))
+		errRun := errors.New("run")
+		if err := a.Run(func(context.Context) error { return errRun }); err != errRun {
+			t.Errorf("Expected %v, got %v", errRun, err)
+		}
+		want := []string{"start a", "stop a"}
+		if !reflect.DeepEqual(r.calls, want) {
+			t.Errorf("Expected %v, got %v", want, r.calls)
+		}
+		if sig := a.Process().Lifetime().Signal; sig != nil {
+			t.Errorf("Expected nil, got %v", sig)
+		}
+		if len(r.logs) != 3 || r.logs[1] != "app: shutting down" {
+			t.Errorf("Unexpected logs %q", r.logs)
+		}
+	})
+
+	t.Run("Start failure", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		var r recorder
+		a := app.New(app.WithLogf(r.logf))
+		errStart := errors.New("unreachable")
+		a.OnStart("a", r.hook("start a", nil), r.hook("stop a", nil))
+		a.OnStart("b", r.hook("start b", errStart), r.hook("stop b", nil))
+		a.OnStart("c", r.hook("start c", nil), r.hook("stop c", nil))
+		a.OnShutdown("flush", r.hook("flush", nil))
+
+		err := a.Run(func(context.Context) error {
+			t.Error("Expected run not to be called")
+			return nil
+		})
+		var errs signals.HookErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Name != "b" {
+			t.Errorf("Expected the failure of b, got %v", err)
+		}
+		if !errors.Is(err, errStart) {
+			t.Errorf("Expected %v, got %v", errStart, err)
+		}
+		want := []string{"start a", "start b", "stop a"}
+		if !reflect.DeepEqual(r.calls, want) {
+			t.Errorf("Expected %v, got %v", want, r.calls)
+		}
+		if a.Ready() {
+			t.Error("Expected not ready")
+		}
+	})
+
+	t.Run("Stop failure", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		var r recorder
+		a := app.New(app.WithLogf(r.logf))
+		errRun, errStop := errors.New("run"), errors.New("stop")
+		a.OnStart("a", nil, r.hook("stop a", errStop))
+		err := a.Run(func(context.Context) error { return errRun })
+		if !errors.Is(err, errRun) || !errors.Is(err, errStop) {
+			t.Errorf("Expected %v and %v, got %v", errRun, errStop, err)
+		}
+	})
+
+	t.Run("Parent canceled", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		var r recorder
+		parent, cancel := context.WithCancel(context.Background())
+		a := app.New(app.WithLogf(r.logf), app.WithContext(parent))
+		err := a.Run(func(ctx context.Context) error {
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		if err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Run again", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		var r recorder
+		a := app.New(app.WithLogf(r.logf))
+		first := a.Process()
+		a.Run(func(ctx context.Context) error {
+			src.Send(syscall.SIGTERM)
+			<-ctx.Done()
+			return nil
+		})
+		a.Run(func(context.Context) error { return nil })
+		if a.Process() == first {
+			t.Error("Expected a new Process for the second Run")
+		}
+		if sig := a.Process().Lifetime().Signal; sig != nil {
+			t.Errorf("Expected nil, got %v", sig)
+		}
+		if sig := first.Lifetime().Signal; sig != syscall.SIGTERM {
+			t.Errorf("Expected %v, got %v", syscall.SIGTERM, sig)
+		}
+	})
+
+	t.Run("Flip on signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		var r recorder
+		a := app.New(
+			app.WithLogf(r.logf),
+			app.WithSignals(signals.Hangup),
+			app.WithContextOptions(signals.WithSoftCancel()),
+		)
+		err := a.Run(func(ctx context.Context) error {
+			src.Send(signals.Hangup)
+			<-signals.ShuttingDown(ctx)
+			if !signalstest.Eventually(func() bool { return !a.Ready() }) {
+				t.Error("Expected not ready before run returns")
+			}
+			if ctx.Err() != nil {
+				t.Errorf("Expected nil, got %v", ctx.Err())
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		if len(r.logs) != 3 || !strings.Contains(r.logs[1], "hangup") {
+			t.Errorf("Unexpected logs %q", r.logs)
+		}
+	})
+}
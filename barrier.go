@@ -0,0 +1,169 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// BarrierEnv is the environment variable by which Barrier.Attach passes the
+// file descriptors of the barrier to a child process.
+const BarrierEnv = "SIGNALS_BARRIER_FDS"
+
+var (
+	// ErrNoBarrier is returned by JoinBarrier in a process not attached to a Barrier.
+	ErrNoBarrier = errors.New("signals: no barrier")
+
+	// ErrParentShutdown is the cause of the cancellation of the context
+	// returned by JoinBarrier when the parent process shuts down.
+	ErrParentShutdown = errors.New("signals: parent shutting down")
+)
+
+// Barrier extends a graceful shutdown to a tree of processes: a parent
+// process announces its shutdown to the child processes attached to the
+// barrier, which join it with JoinBarrier, and waits for their acknowledgment
+// before exiting.
+//
+// The barrier is made of two pipes per child, passed with exec.Cmd.ExtraFiles
+// and BarrierEnv, so it is not available on Windows. A child that exits
+// acknowledges implicitly, and a parent that dies, even abruptly, announces
+// its shutdown implicitly.
+//
+// A zero Barrier is ready to use.
+type Barrier struct {
+	mu      sync.Mutex
+	members []*member
+}
+
+type member struct {
+	notify *os.File   // written by the parent to announce the shutdown
+	ack    *os.File   // read by the parent for the acknowledgment
+	child  []*os.File // the ends of the pipes passed to the child
+}
+
+// Attach arranges for cmd, which must not be started yet, to be attached to
+// the barrier. It must be called before cmd.Start.
+func (b *Barrier) Attach(cmd *exec.Cmd) error {
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("%w: barrier on %s/%s", ErrUnsupported, runtime.GOOS, runtime.GOARCH)
+	}
+	notifyR, notifyW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	ackR, ackW, err := os.Pipe()
+	if err != nil {
+		notifyR.Close()
+		notifyW.Close()
+		return err
+	}
+	fd := 3 + len(cmd.ExtraFiles)
+	cmd.ExtraFiles = append(cmd.ExtraFiles, notifyR, ackW)
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%d,%d", BarrierEnv, fd, fd+1))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.members = append(b.members, &member{notify: notifyW, ack: ackR, child: []*os.File{notifyR, ackW}})
+	return nil
+}
+
+// Release announces the shutdown to the attached children and waits until
+// all of them acknowledge it or exit, or until ctx is done, in which case it
+// returns the error of ctx. Release must be called once the children are
+// started; the children attached before are released only once.
+func (b *Barrier) Release(ctx context.Context) error {
+	b.mu.Lock()
+	members := b.members
+	b.members = nil
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, m := range members {
+		for _, f := range m.child {
+			f.Close()
+		}
+		m.notify.Close()
+		wg.Add(1)
+		go func(m *member) {
+			defer wg.Done()
+			m.ack.Read(make([]byte, 1))
+		}(m)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	defer func() {
+		for _, m := range members {
+			m.ack.Close()
+		}
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// JoinBarrier joins the Barrier the process was attached to by its parent,
+// and returns a copy of the parent context canceled with ErrParentShutdown
+// when the parent process announces its shutdown or dies.
+//
+// The returned ack function acknowledges the shutdown to the parent; it is
+// intended to be called once the cleanup is complete. Exiting acknowledges
+// the shutdown as well.
+//
+// JoinBarrier returns ErrNoBarrier if the process is not attached to a
+// barrier. It removes BarrierEnv from the environment, so that it is not
+// inherited by the children of the process.
+func JoinBarrier(parent context.Context) (ctx context.Context, ack func(), err error) {
+	env, ok := os.LookupEnv(BarrierEnv)
+	if !ok {
+		return nil, nil, ErrNoBarrier
+	}
+	os.Unsetenv(BarrierEnv)
+	fds := strings.Split(env, ",")
+	if len(fds) != 2 {
+		return nil, nil, fmt.Errorf("%w: invalid %s %q", ErrNoBarrier, BarrierEnv, env)
+	}
+	var files [2]*os.File
+	for i, s := range fds {
+		fd, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: invalid %s %q", ErrNoBarrier, BarrierEnv, env)
+		}
+		files[i] = os.NewFile(uintptr(fd), "barrier")
+	}
+	notify, acked := files[0], files[1]
+
+	ctx, cancel := context.WithCancelCause(parent)
+	go func() {
+		// Read returns when the parent closes its end or dies, or when notify is closed below.
+		io.Copy(io.Discard, notify)
+		cancel(ErrParentShutdown)
+	}()
+	go func() {
+		<-ctx.Done()
+		notify.Close()
+	}()
+	var once sync.Once
+	ack = func() {
+		once.Do(func() {
+			acked.Write([]byte{1})
+			acked.Close()
+		})
+	}
+	return ctx, ack, nil
+}
@@ -0,0 +1,78 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+)
+
+const barrierChildEnv = "SIGNALS_TEST_BARRIER_CHILD"
+
+// TestBarrierChild is run as the child process of TestBarrier.
+func TestBarrierChild(t *testing.T) {
+	if os.Getenv(barrierChildEnv) == "" {
+		t.Skip("run by TestBarrier")
+	}
+	ctx, ack, err := signals.JoinBarrier(context.Background())
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("joined")
+	<-ctx.Done()
+	fmt.Println("cleanup:", context.Cause(ctx))
+	ack()
+	time.Sleep(time.Hour) // exiting would acknowledge as well
+}
+
+func TestBarrier(t *testing.T) {
+	var b signals.Barrier
+	cmd := exec.Command(os.Args[0], "-test.run=^TestBarrierChild$")
+	cmd.Env = append(os.Environ(), barrierChildEnv+"=1")
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.Stdout = w
+	if err := b.Attach(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	defer func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	}()
+
+	buf := make([]byte, 64)
+	n, _ := r.Read(buf)
+	if got := string(buf[:n]); got != "joined\n" {
+		t.Fatalf("Expected joined, got %q", got)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := b.Release(ctx); err != nil {
+		t.Fatalf("Expected nil, got %v", err)
+	}
+	n, _ = r.Read(buf)
+	if got, want := string(buf[:n]), "cleanup: "+signals.ErrParentShutdown.Error(); !strings.HasPrefix(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestJoinBarrier(t *testing.T) {
+	t.Setenv(signals.BarrierEnv, "")
+	os.Unsetenv(signals.BarrierEnv)
+	if _, _, err := signals.JoinBarrier(context.Background()); !errors.Is(err, signals.ErrNoBarrier) {
+		t.Errorf("Expected ErrNoBarrier, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// DefaultGracePeriod is the grace period used by Budget when none is
+// configured. It is the default terminationGracePeriodSeconds of Kubernetes.
+const DefaultGracePeriod = 30 * time.Second
+
+// GracePeriodEnv is the environment variable, in seconds, read by NewContext
+// for the grace period when WithGracePeriod is not given. It is typically set
+// to the terminationGracePeriodSeconds of a Kubernetes pod.
+const GracePeriodEnv = "TERMINATION_GRACE_PERIOD_SECONDS"
+
+// Budget returns the time remaining before the process is forcibly terminated,
+// so that cleanup code can divide it among the resources to release.
+//
+// It is the grace period of the innermost context created by NewContext,
+// RunControls or Coordinator.Context, including the extensions granted by
+// Extend, minus the time elapsed since its signal was received, or the whole
+// grace period if no signal was received yet. The grace period is given by
+// WithGracePeriod, or else by the environment variable GracePeriodEnv,
+// or else is DefaultGracePeriod; the latter two also apply to RunControls,
+// Coordinator.Context, and if ctx has no such context. If ctx has an earlier
+// deadline, Budget returns the time until the deadline instead, according to
+// the same clock. Budget never returns a negative duration.
+func Budget(ctx context.Context) time.Duration {
+	grace := gracePeriod(0)
+	var received time.Time
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		grace = st.grace()
+		received = st.receivedAt()
+	}
+	budget := grace
+	if !received.IsZero() {
+		budget -= clock.Since(received)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if d := deadline.Sub(clock.Now()); d < budget {
+			budget = d
+		}
+	}
+	if budget < 0 {
+		return 0
+	}
+	return budget
+}
+
+// gracePeriod returns d if positive, or the grace period from the environment.
+func gracePeriod(d time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	if n, err := strconv.Atoi(os.Getenv(GracePeriodEnv)); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second
+	}
+	return DefaultGracePeriod
+}
@@ -0,0 +1,118 @@
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestBudget(t *testing.T) {
+	t.Run("grace period", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithGracePeriod(10*time.Second),
+		)
+		defer stop()
+		if d := signals.Budget(ctx); d != 10*time.Second {
+			t.Errorf("Expected 10s, got %v", d)
+		}
+		src.Send(syscall.SIGTERM)
+		<-ctx.Done()
+		clk.Advance(3 * time.Second)
+		if d := signals.Budget(ctx); d != 7*time.Second {
+			t.Errorf("Expected 7s, got %v", d)
+		}
+		clk.Advance(10 * time.Second)
+		if d := signals.Budget(ctx); d != 0 {
+			t.Errorf("Expected 0s, got %v", d)
+		}
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		t.Setenv(signals.GracePeriodEnv, "45")
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		if d := signals.Budget(ctx); d != 45*time.Second {
+			t.Errorf("Expected 45s, got %v", d)
+		}
+	})
+
+	t.Run("default", func(t *testing.T) {
+		t.Setenv(signals.GracePeriodEnv, "")
+		if d := signals.Budget(context.Background()); d != signals.DefaultGracePeriod {
+			t.Errorf("Expected %v, got %v", signals.DefaultGracePeriod, d)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if d := signals.Budget(ctx); d <= 0 || d > time.Second {
+			t.Errorf("Expected at most 1s, got %v", d)
+		}
+	})
+
+	t.Run("fake clock deadline", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		ctx, cancel := context.WithDeadline(context.Background(), clk.Now().Add(5*time.Second))
+		defer cancel()
+		if d := signals.Budget(ctx); d != 5*time.Second {
+			t.Errorf("Expected 5s, got %v", d)
+		}
+		clk.Advance(2 * time.Second)
+		if d := signals.Budget(ctx); d != 3*time.Second {
+			t.Errorf("Expected 3s, got %v", d)
+		}
+	})
+
+	t.Run("RunControls", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clk := signalstest.NewFakeClock(t)
+		t.Setenv(signals.GracePeriodEnv, "")
+		signals.RunControls(context.Background(), func(ctx context.Context, c *signals.Controls) error {
+			if d := signals.Budget(ctx); d != signals.DefaultGracePeriod {
+				t.Errorf("Expected %v, got %v", signals.DefaultGracePeriod, d)
+			}
+			src.Send(syscall.SIGTERM)
+			<-ctx.Done()
+			clk.Advance(time.Second)
+			if d := signals.Budget(ctx); d != signals.DefaultGracePeriod-time.Second {
+				t.Errorf("Expected %v, got %v", signals.DefaultGracePeriod-time.Second, d)
+			}
+			return nil
+		}, syscall.SIGTERM)
+	})
+
+	t.Run("Coordinator", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clk := signalstest.NewFakeClock(t)
+		t.Setenv(signals.GracePeriodEnv, "20")
+		c := signals.Coordinator{
+			Lease: leaseFunc(func(context.Context) error {
+				clk.Advance(10 * time.Second)
+				return nil
+			}),
+		}
+		ctx, stop := c.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		if d := signals.Budget(ctx); d != 20*time.Second {
+			t.Errorf("Expected 20s, got %v", d)
+		}
+		start := clk.Now()
+		src.Send(syscall.SIGTERM)
+		<-ctx.Done()
+		if d := signals.Budget(ctx); d != 10*time.Second {
+			t.Errorf("Expected 10s, got %v", d)
+		}
+		if at, ok := signals.ReceivedAt(ctx); !ok || !at.Equal(start) {
+			t.Errorf("Expected %v, got %v", start, at)
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package signals
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// Chaos delivers signals chosen at random among sigs to the subscribers of this
+// package, at random intervals averaging rate signals per second, until ctx is
+// done. It is meant for test and staging builds, to exercise shutdown and
+// reload paths continuously.
+//
+// The signals are delivered like Event values with Inject, so no OS signal is
+// ever sent: only the subscribers of this package, such as Wait and Context,
+// receive them, and the default behavior of the signals never applies. Like OS
+// signals, signals other than events are also delivered to the subscribers of
+// all signals.
+//
+// Chaos panics if no signals are provided or if rate is not positive.
+func Chaos(ctx context.Context, rate float64, sigs ...os.Signal) {
+	if len(sigs) == 0 {
+		panic("signals: Chaos without signals")
+	}
+	if rate <= 0 {
+		panic("signals: non-positive rate for Chaos")
+	}
+	for {
+		// Exponential intervals make the injections a Poisson process.
+		timer := clock.NewTimer(time.Duration(rand.ExpFloat64() / rate * float64(time.Second)))
+		select {
+		case <-timer.C():
+			sig := sigs[rand.Intn(len(sigs))]
+			_, event := sig.(Event)
+			source.Inject(sig, !event)
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package signals_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestChaos(t *testing.T) {
+	clk := signalstest.NewFakeClock(t)
+	signalstest.NewFakeSource(t)
+	ctx, stop := signals.Context(context.Background())
+	defer stop()
+	hup := signals.Subscribe(1, signals.Hangup)
+	defer hup.Close()
+
+	chaos, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		signals.Chaos(chaos, 1, signals.Hangup)
+	}()
+	for ctx.Err() == nil {
+		clk.BlockUntil(1)
+		clk.Advance(time.Hour)
+		time.Sleep(time.Millisecond)
+	}
+	signalstest.AssertCanceledBy(t, ctx, signals.Hangup)
+	if sig := <-hup.C; sig != signals.Hangup {
+		t.Errorf("Expected %v, got %v", signals.Hangup, sig)
+	}
+	cancel()
+	<-done
+}
+
+func TestChaosPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic")
+		}
+	}()
+	signals.Chaos(context.Background(), 1)
+}
@@ -0,0 +1,48 @@
+package signals
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// ErrUnsupported is returned by Check for signals that cannot be delivered on this platform.
+var ErrUnsupported = errors.New("signals: unsupported signal")
+
+// ErrUncatchable is returned by Check for signals that no process can handle,
+// such as SIGKILL and SIGSTOP.
+var ErrUncatchable = errors.New("signals: uncatchable signal")
+
+// Check reports whether the specified signals can be delivered to the process on this platform.
+// It returns an error wrapping ErrUncatchable or ErrUnsupported for the first signal that cannot.
+//
+// SIGKILL and SIGSTOP (os.Kill on every platform) are uncatchable: the system acts on
+// them without ever notifying the process.
+//
+// On js/wasm, OS signals do not exist. Under Node.js, SIGINT and SIGTERM are mapped from
+// the process events of the same name; in a browser, SIGTERM is mapped from the pagehide event.
+// Other signals are unsupported.
+// On wasip1, no signal is supported.
+// An Event is supported on every platform, since it is delivered by Inject.
+//
+// Wait, Context and the other functions of this package accept unsupported signals,
+// but they are never received; use Check, or WithStrict, to fail early instead of waiting forever.
+func Check(signals ...os.Signal) error {
+	for _, sig := range signals {
+		if _, ok := sig.(Event); ok {
+			continue
+		}
+		for _, u := range uncatchable {
+			if sig == u {
+				return fmt.Errorf("%w: %v", ErrUncatchable, sig)
+			}
+		}
+		if !source.Supported(sig) {
+			return fmt.Errorf("%w: %v on %s/%s", ErrUnsupported, sig, runtime.GOOS, runtime.GOARCH)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+//go:build js && wasm
+
+package signals_test
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"syscall/js"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestCheckJS(t *testing.T) {
+	// The tests run under Node.js, where SIGINT and SIGTERM are mapped from
+	// the process events of the same name.
+	t.Run("supported", func(t *testing.T) {
+		if err := signals.Check(signals.Interrupt, signals.Terminate, syscall.SIGINT, syscall.SIGTERM); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		for _, sig := range []os.Signal{signals.Hangup, signals.Alarm, syscall.SIGQUIT, syscall.SIGCHLD} {
+			if err := signals.Check(sig); !errors.Is(err, signals.ErrUnsupported) {
+				t.Errorf("Expected ErrUnsupported for %v, got %v", sig, err)
+			}
+		}
+	})
+
+	t.Run("uncatchable", func(t *testing.T) {
+		if err := signals.Check(os.Kill); !errors.Is(err, signals.ErrUncatchable) {
+			t.Errorf("Expected ErrUncatchable, got %v", err)
+		}
+	})
+}
+
+func TestHostEventsJS(t *testing.T) {
+	sub := signals.Subscribe(1, signals.Interrupt)
+	defer sub.Close()
+	js.Global().Get("process").Call("emit", "SIGINT")
+	select {
+	case sig := <-sub.C:
+		if sig != syscall.SIGINT {
+			t.Errorf("Expected %v, got %v", syscall.SIGINT, sig)
+		}
+	case <-time.After(signalstest.AssertTimeout):
+		t.Error("Expected the SIGINT event of the process to be relayed")
+	}
+}
+
+func TestNamesJS(t *testing.T) {
+	if signals.Interrupt != syscall.SIGINT {
+		t.Errorf("Expected %v, got %v", syscall.SIGINT, signals.Interrupt)
+	}
+	if signals.Terminate != syscall.SIGTERM {
+		t.Errorf("Expected %v, got %v", syscall.SIGTERM, signals.Terminate)
+	}
+	for _, sig := range []os.Signal{signals.Hangup, signals.Alarm} {
+		if _, ok := sig.(syscall.Signal); ok {
+			t.Errorf("Expected %v to be a placeholder, got a syscall.Signal", sig)
+		}
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package signals
+
+import "os"
+
+var uncatchable = []os.Signal{os.Kill}
@@ -0,0 +1,52 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestCheck(t *testing.T) {
+	if err := signals.Check(syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+	if err := signals.Check(); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+}
+
+func TestCheckUncatchable(t *testing.T) {
+	for _, sig := range []os.Signal{syscall.SIGKILL, syscall.SIGSTOP} {
+		t.Run(sig.String(), func(t *testing.T) {
+			err := signals.Check(syscall.SIGINT, sig)
+			if !errors.Is(err, signals.ErrUncatchable) {
+				t.Errorf("Expected ErrUncatchable, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithStrict(t *testing.T) {
+	signalstest.NewFakeSource(t)
+	t.Run("valid", func(t *testing.T) {
+		_, stop := signals.NewContext(context.Background(), signals.WithStrict(), signals.WithSignals(syscall.SIGINT))
+		stop()
+	})
+	t.Run("uncatchable", func(t *testing.T) {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, signals.ErrUncatchable) {
+				t.Errorf("Expected ErrUncatchable, got %v", err)
+			}
+		}()
+		_, stop := signals.NewContext(context.Background(), signals.WithStrict(), signals.WithSignals(os.Kill))
+		stop()
+	})
+}
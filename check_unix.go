@@ -0,0 +1,10 @@
+//go:build unix
+
+package signals
+
+import (
+	"os"
+	"syscall"
+)
+
+var uncatchable = []os.Signal{syscall.SIGKILL, syscall.SIGSTOP}
@@ -0,0 +1,90 @@
+package signals
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// Defer registers fn to be called in its own goroutine after ctx is done.
+//
+// The call is tracked process-wide so that WaitForCleanup can block until
+// fn and every other cleanup registered with Defer have returned.
+func Defer(ctx context.Context, fn func()) {
+	cleanups.add()
+	go func() {
+		defer cleanups.done()
+		<-ctx.Done()
+		fn()
+	}()
+}
+
+// WaitForCleanup waits until all cleanups registered with Defer have returned.
+//
+// It returns nil once no cleanup is pending, context.DeadlineExceeded if the
+// timeout expires first, or the error of ctx if ctx is done first.
+// A timeout of zero or less means no timeout.
+//
+// WaitForCleanup is intended to be called by the main goroutine right before
+// the process exits, so that cleanups are not cut off by os.Exit.
+func WaitForCleanup(ctx context.Context, timeout time.Duration) error {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := clock.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C()
+	}
+	select {
+	case <-cleanups.wait():
+		return nil
+	case <-expired:
+		return context.DeadlineExceeded
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var cleanups cleanupRegistry
+
+// cleanupRegistry counts pending cleanups.
+// Unlike sync.WaitGroup, it allows new cleanups to be added while waiting.
+type cleanupRegistry struct {
+	mu      sync.Mutex
+	pending int
+	idle    chan struct{} // closed when pending drops to zero
+}
+
+func (r *cleanupRegistry) add() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == 0 {
+		r.idle = make(chan struct{})
+	}
+	r.pending++
+}
+
+func (r *cleanupRegistry) done() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending--
+	if r.pending == 0 {
+		close(r.idle)
+	}
+}
+
+func (r *cleanupRegistry) wait() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending == 0 {
+		return closedChan
+	}
+	return r.idle
+}
+
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
@@ -0,0 +1,73 @@
+package signals_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestWaitForCleanup(t *testing.T) {
+	t.Run("Cleanups finished", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var count atomic.Int32
+		for i := 0; i < 3; i++ {
+			signals.Defer(ctx, func() {
+				time.Sleep(10 * time.Millisecond)
+				count.Add(1)
+			})
+		}
+		cancel()
+
+		if err := signals.WaitForCleanup(context.Background(), 5*time.Second); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		if n := count.Load(); n != 3 {
+			t.Errorf("Expected 3, got %d", n)
+		}
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		signals.Defer(ctx, func() { <-release })
+		cancel()
+		defer func() {
+			close(release)
+			signals.WaitForCleanup(context.Background(), 0)
+		}()
+
+		c := signalstest.NewFakeClock(t)
+		errc := make(chan error, 1)
+		go func() { errc <- signals.WaitForCleanup(context.Background(), time.Minute) }()
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+		if err := <-errc; err != context.DeadlineExceeded {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("Context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer func() {
+			cancel()
+			signals.WaitForCleanup(context.Background(), 0)
+		}()
+		signals.Defer(ctx, func() {})
+
+		waitCtx, waitCancel := context.WithCancel(context.Background())
+		waitCancel()
+		if err := signals.WaitForCleanup(waitCtx, 0); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Nothing pending", func(t *testing.T) {
+		if err := signals.WaitForCleanup(context.Background(), time.Second); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,314 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// Canceled is the cause of the cancellation of a context created by Context
+// when one of its signals is received. It can be retrieved with context.Cause.
+//
+// errors.Is(Canceled{...}, context.Canceled) reports true.
+type Canceled struct {
+	Signal os.Signal
+}
+
+// Error implements the error interface.
+func (c Canceled) Error() string {
+	return "signals: received " + c.Signal.String()
+}
+
+// Is reports whether target is context.Canceled.
+func (c Canceled) Is(target error) bool {
+	return target == context.Canceled
+}
+
+// NewCanceled returns the Canceled holding sig, for code that cancels contexts
+// on signals by its own means, such as a custom source of signals, so that
+// FromContext, CauseKind and AsCanceled recognize the cancellation.
+func NewCanceled(sig os.Signal) Canceled {
+	return Canceled{Signal: sig}
+}
+
+// AsCanceled returns the first Canceled in the tree of err, as errors.As does,
+// and reports whether one was found.
+func AsCanceled(err error) (Canceled, bool) {
+	var c Canceled
+	ok := errors.As(err, &c)
+	return c, ok
+}
+
+// Context returns a copy of the parent context that is canceled when one of
+// the specified signals is received, when the returned stop function is called,
+// or when the parent context is done, whichever happens first.
+//
+// When a signal is received, the cause of the cancellation is a Canceled
+// holding the signal, and FromContext reports it.
+//
+// If no signals are provided, all incoming signals will be relayed.
+// Otherwise, only the provided signals will be monitored.
+//
+// The stop function unregisters the signal behavior and releases the resources
+// associated with the context. Code should call stop as soon as the operations
+// running in this context complete.
+//
+// If the parent context is already done, nothing is registered and no goroutine
+// is started, which keeps request-scoped usage cheap.
+//
+// Context is equivalent to NewContext(parent, WithSignals(signals...)).
+func Context(parent context.Context, signals ...os.Signal) (ctx context.Context, stop func()) {
+	return NewContext(parent, WithSignals(signals...))
+}
+
+// NewContext is like Context, configured by options.
+// Without WithSignals, all incoming signals will be relayed.
+func NewContext(parent context.Context, options ...Option) (ctx context.Context, stop func()) {
+	var cfg config
+	for _, o := range options {
+		o(&cfg)
+	}
+	if cfg.strict {
+		if err := Check(cfg.signals...); err != nil {
+			panic(err)
+		}
+	}
+
+	st := &state{policy: cfg.policy(), onExtend: cfg.onExtend, shuttingDown: make(chan struct{})}
+	parent = context.WithValue(parent, stateKey{}, st)
+	ctx, cancel := context.WithCancelCause(parent)
+	st.hard, st.cancelHard = context.WithCancelCause(parent)
+	if parent.Err() != nil {
+		// Fast path: the parent is already done, so ctx is already canceled
+		// and there is nothing to register.
+		st.shutdown()
+		return ctx, func() {
+			cancel(nil)
+			st.cancelHard(nil)
+		}
+	}
+	size := 1
+	if cfg.keepListening || len(cfg.filters) != 0 {
+		size = 8
+	}
+	ch := make(chan os.Signal, size)
+	source.Notify(ch, cfg.signals...)
+	released := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			source.Stop(ch)
+			cancel(nil)
+			st.cancelHard(nil)
+			st.shutdown()
+			close(released)
+		})
+	}
+	in := (<-chan os.Signal)(ch)
+	var quit chan struct{}
+	if len(cfg.filters) != 0 {
+		quit = make(chan struct{})
+		in = filter(&cfg, st, ch, quit)
+	}
+	go func() {
+		if quit != nil {
+			defer close(quit)
+		}
+		if cfg.lockedThread {
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+		}
+		select {
+		case sig := <-in:
+			if cfg.softCancel {
+				st.set(sig)
+			} else if !st.cancel(ctx, cancel, sig) {
+				st.lost(ctx, sig, clock.Now())
+				break
+			}
+			st.shutdown()
+			if cfg.escalation != nil {
+				go escalate(ctx, cfg.escalation, st, released)
+			}
+			if cfg.countdown != nil {
+				go countdown(cfg.countdown, st, released)
+			}
+			for cfg.keepListening {
+				select {
+				case sig := <-in:
+					st.add(sig)
+				case <-released:
+					return
+				}
+			}
+		case <-ctx.Done():
+			select {
+			case sig := <-in:
+				st.lost(ctx, sig, clock.Now())
+			default:
+			}
+		}
+		st.shutdown()
+		source.Stop(ch)
+	}()
+	return ctx, stop
+}
+
+// FromContext returns the signal that canceled the context created by Context
+// from which ctx derives, and reports whether such a signal was received.
+func FromContext(ctx context.Context) (os.Signal, bool) {
+	if c, ok := AsCanceled(context.Cause(ctx)); ok {
+		return c.Signal, true
+	}
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		return st.get()
+	}
+	return nil, false
+}
+
+// ReceivedAt returns the time the signal reported by FromContext was received,
+// according to the clock of this package, and reports whether such a signal
+// was received.
+func ReceivedAt(ctx context.Context) (time.Time, bool) {
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		if received := st.receivedAt(); !received.IsZero() {
+			return received, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ShuttingDown returns a channel that is closed when the shutdown of the context
+// created by NewContext from which ctx derives begins: when one of its signals
+// is received, or when it is done. With WithSoftCancel, the channel is the only
+// notice of the signal.
+//
+// If ctx has no context created by NewContext, ShuttingDown returns ctx.Done().
+func ShuttingDown(ctx context.Context) <-chan struct{} {
+	if st, ok := ctx.Value(stateKey{}).(*state); ok && st.shuttingDown != nil {
+		return st.shuttingDown
+	}
+	return ctx.Done()
+}
+
+// Unwrap returns the cause of the cancellation of ctx, as context.Cause does,
+// and reports whether it is the reception of a signal, in which case the cause
+// is a Canceled. A cause given by an ancestor of ctx, for example with
+// context.WithCancelCause, is returned as is, through any number of contexts of
+// this package. If ctx is not done, Unwrap returns nil and false.
+func Unwrap(ctx context.Context) (cause error, fromSignal bool) {
+	cause = context.Cause(ctx)
+	_, fromSignal = AsCanceled(cause)
+	return cause, fromSignal
+}
+
+// History returns the signals received by the context created by Context or
+// NewContext from which ctx derives, in order of arrival.
+//
+// Only the first signal is recorded unless the context was created with WithKeepListening.
+func History(ctx context.Context) []os.Signal {
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		return st.signals()
+	}
+	return nil
+}
+
+type stateKey struct{}
+
+// state records what happened to a context created by Context.
+type state struct {
+	mu       sync.Mutex
+	sig      os.Signal
+	received time.Time
+	history  []os.Signal
+	race     *Race
+	filtered atomic.Uint64
+	policy   Policy // immutable
+
+	extended   time.Duration
+	extendedCh chan struct{} // closed when extended changes
+	onExtend   func(Extension)
+
+	hard       context.Context
+	cancelHard context.CancelCauseFunc
+
+	shuttingDown chan struct{}
+	shutdownOnce sync.Once
+}
+
+// shutdown closes s.shuttingDown.
+func (s *state) shutdown() {
+	s.shutdownOnce.Do(func() { close(s.shuttingDown) })
+}
+
+func (s *state) set(sig os.Signal) {
+	s.setAt(sig, clock.Now())
+}
+
+// setAt is like set for sig received at the given time.
+func (s *state) setAt(sig os.Signal, received time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sig = sig
+	if sig == nil {
+		s.received = time.Time{}
+		s.history = nil
+	} else {
+		s.received = received
+		s.history = []os.Signal{sig}
+	}
+}
+
+// cancel cancels ctx by sig and records sig,
+// and reports whether ctx was canceled by sig rather than by its parent.
+func (s *state) cancel(ctx context.Context, cancel context.CancelCauseFunc, sig os.Signal) bool {
+	return s.cancelAt(ctx, cancel, sig, clock.Now())
+}
+
+// cancelAt is like cancel for sig received at the given time,
+// followed by the signals of more.
+func (s *state) cancelAt(ctx context.Context, cancel context.CancelCauseFunc, sig os.Signal, received time.Time, more ...os.Signal) bool {
+	// The state is recorded before the cancellation so that it is
+	// visible as soon as ctx is done, and reverted if the parent won the race.
+	s.setAt(sig, received)
+	for _, m := range more {
+		s.add(m)
+	}
+	cancel(Canceled{Signal: sig})
+	if cause, ok := context.Cause(ctx).(Canceled); !ok || cause.Signal != sig {
+		s.set(nil)
+		return false
+	}
+	return true
+}
+
+func (s *state) add(sig os.Signal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history = append(s.history, sig)
+}
+
+func (s *state) signals() []os.Signal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]os.Signal(nil), s.history...)
+}
+
+func (s *state) receivedAt() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.received
+}
+
+func (s *state) get() (os.Signal, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sig, s.sig != nil
+}
@@ -0,0 +1,361 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func ExampleContext() {
+	ctx, stop := signals.Context(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// Stopping cancels the context without a signal.
+	stop()
+
+	<-ctx.Done()
+	if sig, ok := signals.FromContext(ctx); ok {
+		fmt.Printf("Received signal: %v\n", sig)
+	} else {
+		fmt.Println("Stopped")
+	}
+	// Output:
+	// Stopped
+}
+
+func TestContext(t *testing.T) {
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+
+		src.WaitSubscribed(ctx, syscall.SIGTERM)
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+
+		cause := context.Cause(ctx)
+		if !errors.Is(cause, context.Canceled) {
+			t.Errorf("Expected errors.Is(%v, context.Canceled)", cause)
+		}
+		if want := (signals.Canceled{Signal: syscall.SIGTERM}); cause != want {
+			t.Errorf("Expected %v, got %v", want, cause)
+		}
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGINT)
+		src.AssertSubscribed(t, syscall.SIGINT)
+
+		stop()
+		src.AssertNotSubscribed(t, syscall.SIGINT)
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if sig, ok := signals.FromContext(ctx); ok {
+			t.Errorf("Expected no signal, got %v", sig)
+		}
+	})
+
+	t.Run("Parent canceled", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		parent, cancel := context.WithCancel(context.Background())
+		ctx, stop := signals.Context(parent, syscall.SIGINT)
+		defer stop()
+
+		cancel()
+		<-ctx.Done()
+		if sig, ok := signals.FromContext(ctx); ok {
+			t.Errorf("Expected no signal, got %v", sig)
+		}
+
+		signalstest.Eventually(func() bool { return src.Subscribers(syscall.SIGINT) == 0 })
+		src.AssertNotSubscribed(t, syscall.SIGINT)
+	})
+
+	t.Run("Derived context", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), signals.Hangup)
+		defer stop()
+		child, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		src.Send(signals.Hangup)
+		signalstest.AssertCanceledBy(t, child, signals.Hangup)
+	})
+}
+
+func TestNewContext(t *testing.T) {
+	t.Run("History", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.NewContext(context.Background(), signals.WithSignals(syscall.SIGINT))
+		defer stop()
+
+		src.Send(syscall.SIGINT)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGINT)
+		waitCtx, waitCancel := context.WithTimeout(context.Background(), signalstest.AssertTimeout)
+		defer waitCancel()
+		if err := src.WaitNotSubscribed(waitCtx, syscall.SIGINT); err != nil {
+			t.Fatal(err)
+		}
+		if h := signals.History(ctx); len(h) != 1 || h[0] != syscall.SIGINT {
+			t.Errorf("Expected [SIGINT], got %v", h)
+		}
+	})
+
+	t.Run("WithKeepListening", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGINT),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithKeepListening(),
+		)
+
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+		src.AssertSubscribed(t, syscall.SIGINT)
+
+		src.Send(syscall.SIGINT)
+		signalstest.Eventually(func() bool { return len(signals.History(ctx)) >= 2 })
+		h := signals.History(ctx)
+		if len(h) != 2 || h[0] != syscall.SIGTERM || h[1] != syscall.SIGINT {
+			t.Errorf("Expected [SIGTERM SIGINT], got %v", h)
+		}
+		if sig, _ := signals.FromContext(ctx); sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", sig)
+		}
+
+		stop()
+		src.AssertNotSubscribed(t, syscall.SIGINT)
+	})
+
+	t.Run("WithLockedThread", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGINT),
+			signals.WithLockedThread(),
+		)
+		defer stop()
+
+		src.Send(syscall.SIGINT)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGINT)
+	})
+
+	t.Run("WithSoftCancel", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithSoftCancel(),
+		)
+		defer stop()
+
+		src.Send(syscall.SIGTERM)
+		select {
+		case <-signals.ShuttingDown(ctx):
+		case <-time.After(signalstest.AssertTimeout):
+			t.Fatal("Expected ShuttingDown to be closed")
+		}
+		if err := ctx.Err(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		if sig, ok := signals.FromContext(ctx); !ok || sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", sig)
+		}
+
+		stop()
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestShuttingDown(t *testing.T) {
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGINT)
+		defer stop()
+		select {
+		case <-signals.ShuttingDown(ctx):
+			t.Fatal("Expected ShuttingDown to be open")
+		default:
+		}
+		src.Send(syscall.SIGINT)
+		<-signals.ShuttingDown(ctx)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGINT)
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGINT)
+		stop()
+		select {
+		case <-signals.ShuttingDown(ctx):
+		default:
+			t.Error("Expected ShuttingDown to be closed")
+		}
+	})
+
+	t.Run("Parent done", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		parent, cancel := context.WithCancel(context.Background())
+		cancel()
+		ctx, stop := signals.Context(parent, syscall.SIGINT)
+		defer stop()
+		select {
+		case <-signals.ShuttingDown(ctx):
+		default:
+			t.Error("Expected ShuttingDown to be closed")
+		}
+	})
+
+	t.Run("Without NewContext", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		if signals.ShuttingDown(ctx) != ctx.Done() {
+			t.Error("Expected ctx.Done()")
+		}
+		cancel()
+	})
+}
+
+func TestContextParentDone(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	errParent := errors.New("parent")
+	parent, cancel := context.WithCancelCause(context.Background())
+	cancel(errParent)
+
+	ctx, stop := signals.Context(parent, syscall.SIGINT)
+	defer stop()
+	if err := ctx.Err(); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if cause := context.Cause(ctx); cause != errParent {
+		t.Errorf("Expected %v, got %v", errParent, cause)
+	}
+
+	called := false
+	signals.Run(parent, func(ctx context.Context) error {
+		called = true
+		if ctx.Err() == nil {
+			t.Error("Expected a canceled context")
+		}
+		return nil
+	}, syscall.SIGINT)
+	if !called {
+		t.Error("Expected run to be called")
+	}
+	signals.RunControls(parent, func(ctx context.Context, c *signals.Controls) error {
+		return nil
+	}, syscall.SIGINT)
+	var c signals.Coordinator
+	_, stopCoordinator := c.Context(parent, syscall.SIGINT)
+	stopCoordinator()
+
+	if n := src.Registrations(); n != 0 {
+		t.Errorf("Expected no registration, got %d", n)
+	}
+}
+
+func TestUnwrap(t *testing.T) {
+	t.Run("not done", func(t *testing.T) {
+		if cause, ok := signals.Unwrap(context.Background()); cause != nil || ok {
+			t.Errorf("Expected nil and false, got %v and %v", cause, ok)
+		}
+	})
+
+	t.Run("parent cause", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		errParent := errors.New("parent")
+		parent, cancel := context.WithCancelCause(context.Background())
+		outer, stopOuter := signals.Context(parent, syscall.SIGTERM)
+		defer stopOuter()
+		inner, stopInner := signals.Context(outer, syscall.SIGINT)
+		defer stopInner()
+		cancel(errParent)
+		<-inner.Done()
+		if cause, ok := signals.Unwrap(inner); cause != errParent || ok {
+			t.Errorf("Expected %v and false, got %v and %v", errParent, cause, ok)
+		}
+		if _, ok := signals.FromContext(inner); ok {
+			t.Error("Expected no signal")
+		}
+	})
+
+	t.Run("signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		outer, stopOuter := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stopOuter()
+		inner, stopInner := signals.Context(outer, syscall.SIGINT)
+		defer stopInner()
+		src.Send(syscall.SIGTERM)
+		<-inner.Done()
+		cause, ok := signals.Unwrap(inner)
+		if !ok {
+			t.Fatalf("Expected a signal, got %v", cause)
+		}
+		if c := cause.(signals.Canceled); c.Signal != syscall.SIGTERM {
+			t.Errorf("Expected %v, got %v", syscall.SIGTERM, c.Signal)
+		}
+	})
+}
+
+func TestAsCanceled(t *testing.T) {
+	t.Run("Wrapped", func(t *testing.T) {
+		err := fmt.Errorf("shutting down: %w", signals.NewCanceled(syscall.SIGTERM))
+		c, ok := signals.AsCanceled(err)
+		if !ok || c.Signal != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", c.Signal)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Custom cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		cancel(signals.NewCanceled(signals.Hangup))
+		if sig, ok := signals.FromContext(ctx); !ok || sig != signals.Hangup {
+			t.Errorf("Expected SIGHUP, got %v", sig)
+		}
+		if k := signals.CauseKind(ctx); k != signals.KindSignal {
+			t.Errorf("Expected %v, got %v", signals.KindSignal, k)
+		}
+	})
+
+	t.Run("Other error", func(t *testing.T) {
+		if c, ok := signals.AsCanceled(context.Canceled); ok {
+			t.Errorf("Expected no Canceled, got %v", c)
+		}
+		if _, ok := signals.AsCanceled(nil); ok {
+			t.Error("Expected no Canceled for nil")
+		}
+	})
+}
+
+func TestReceivedAt(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	clk := signalstest.NewFakeClock(t)
+	ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+	defer stop()
+	if _, ok := signals.ReceivedAt(ctx); ok {
+		t.Error("Expected no receipt before the signal")
+	}
+	received := clk.Now()
+	src.Send(syscall.SIGTERM)
+	signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+	clk.Advance(time.Second)
+	if at, ok := signals.ReceivedAt(signals.Detach(ctx)); !ok || !at.Equal(received) {
+		t.Errorf("Expected %v, got %v", received, at)
+	}
+	if _, ok := signals.ReceivedAt(context.Background()); ok {
+		t.Error("Expected no receipt without Context")
+	}
+}
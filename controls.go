@@ -0,0 +1,156 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// ErrTriggered is the cause of the cancellation of the context of RunControls
+// when Controls.Trigger is called.
+var ErrTriggered = errors.New("signals: shutdown triggered")
+
+// Controls lets the run function of RunControls interact with the shutdown machinery.
+type Controls struct {
+	st     *state
+	cancel context.CancelCauseFunc
+	ctx    context.Context
+
+	mu       sync.Mutex
+	delay    time.Duration // delay applied when a signal is received
+	deadline time.Time     // time the pending cancellation is due, if a signal was received
+	extended chan struct{} // closed and replaced when deadline is extended
+}
+
+// Trigger starts the shutdown as if a signal was received, without delay.
+// The cause of the cancellation is ErrTriggered.
+func (c *Controls) Trigger() {
+	c.cancel(ErrTriggered)
+}
+
+// Delay postpones the cancellation of the context caused by a signal by extra,
+// for example to finish the current batch of work.
+//
+// Before a signal is received, the delays accumulate and apply to the next signal.
+// After a signal is received, Delay extends the pending cancellation.
+// A second signal cancels the context immediately, regardless of the delay.
+// Delay reports false if the context is already done.
+func (c *Controls) Delay(extra time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx.Err() != nil {
+		return false
+	}
+	if c.deadline.IsZero() {
+		c.delay += extra
+	} else {
+		c.deadline = c.deadline.Add(extra)
+		close(c.extended)
+		c.extended = make(chan struct{})
+	}
+	return true
+}
+
+// History returns the signals received so far, in order of arrival, once the
+// context is canceled by a signal; it is empty while the cancellation is delayed.
+func (c *Controls) History() []os.Signal {
+	return c.st.signals()
+}
+
+// RunControls is like Run, except that run also receives Controls.
+//
+// When a signal is received, the context is canceled after the delay requested
+// with Controls.Delay, if any. Further signals are recorded in the history until
+// run returns, instead of triggering their default behavior.
+func RunControls(parent context.Context, run func(ctx context.Context, c *Controls) error, signals ...os.Signal) (Report, error) {
+	st := &state{policy: (&config{signals: signals}).policy()}
+	parent = context.WithValue(parent, stateKey{}, st)
+	ctx, cancel := context.WithCancelCause(parent)
+	defer cancel(nil)
+	c := &Controls{st: st, cancel: cancel, ctx: ctx, extended: make(chan struct{})}
+	if ctx.Err() != nil {
+		r := Report{Started: clock.Now()}
+		r.Err = run(ctx, c)
+		r.Returned = clock.Now()
+		return r, r.Err
+	}
+
+	ch := make(chan os.Signal, 8)
+	source.Notify(ch, signals...)
+	defer source.Stop(ch)
+	returned := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		c.watch(ch, returned)
+	}()
+	defer wg.Wait()
+	defer close(returned)
+
+	r := Report{Started: clock.Now()}
+	r.Err = run(ctx, c)
+	r.Returned = clock.Now()
+	r.Signal, _ = st.get()
+	r.Received = st.receivedAt()
+	return r, r.Err
+}
+
+func (c *Controls) watch(ch <-chan os.Signal, returned <-chan struct{}) {
+	var sig os.Signal
+	select {
+	case sig = <-ch:
+	case <-c.ctx.Done():
+		return
+	case <-returned:
+		return
+	}
+
+	// The signal is recorded only once the cancellation is applied, so that
+	// FromContext does not report it while the context is not done.
+	received := clock.Now()
+	var more []os.Signal
+	c.mu.Lock()
+	c.deadline = received.Add(c.delay)
+	c.mu.Unlock()
+	for {
+		c.mu.Lock()
+		wait := c.deadline.Sub(clock.Now())
+		extended := c.extended
+		c.mu.Unlock()
+		if wait <= 0 {
+			break
+		}
+		timer := clock.NewTimer(wait)
+		select {
+		case <-timer.C():
+		case <-extended:
+		case s := <-ch:
+			more = append(more, s)
+			wait = 0
+		case <-c.ctx.Done():
+		case <-returned:
+			timer.Stop()
+			return
+		}
+		timer.Stop()
+		if wait <= 0 || c.ctx.Err() != nil {
+			break
+		}
+	}
+	c.st.cancelAt(c.ctx, c.cancel, sig, received, more...)
+
+	for {
+		select {
+		case s := <-ch:
+			c.st.add(s)
+		case <-returned:
+			return
+		}
+	}
+}
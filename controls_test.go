@@ -0,0 +1,109 @@
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestRunControls(t *testing.T) {
+	t.Run("Delay", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clock := signalstest.NewFakeClock(t)
+
+		report, err := signals.RunControls(context.Background(), func(ctx context.Context, c *signals.Controls) error {
+			c.Delay(5 * time.Second)
+			src.Send(syscall.SIGTERM)
+			clock.BlockUntil(1)
+			if err := ctx.Err(); err != nil {
+				t.Errorf("Expected the cancellation to be delayed, got %v", err)
+			}
+			if sig, ok := signals.FromContext(ctx); ok {
+				t.Errorf("Expected no signal while delayed, got %v", sig)
+			}
+
+			if !c.Delay(5 * time.Second) {
+				t.Error("Expected Delay to succeed")
+			}
+			clock.Advance(5 * time.Second)
+			clock.BlockUntil(1)
+			if err := ctx.Err(); err != nil {
+				t.Errorf("Expected the cancellation to be extended, got %v", err)
+			}
+
+			clock.Advance(5 * time.Second)
+			signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+			if c.Delay(time.Second) {
+				t.Error("Expected Delay to fail after the cancellation")
+			}
+			return nil
+		}, syscall.SIGINT, syscall.SIGTERM)
+
+		if err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		if report.Signal != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", report.Signal)
+		}
+		if d := report.Shutdown(); d != 10*time.Second {
+			t.Errorf("Expected 10s, got %v", d)
+		}
+	})
+
+	t.Run("Second signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clock := signalstest.NewFakeClock(t)
+
+		signals.RunControls(context.Background(), func(ctx context.Context, c *signals.Controls) error {
+			c.Delay(time.Minute)
+			src.Send(syscall.SIGTERM)
+			clock.BlockUntil(1)
+			src.Send(syscall.SIGINT)
+			signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+
+			h := c.History()
+			if len(h) != 2 || h[0] != syscall.SIGTERM || h[1] != syscall.SIGINT {
+				t.Errorf("Expected [SIGTERM SIGINT], got %v", h)
+			}
+			return nil
+		}, syscall.SIGINT, syscall.SIGTERM)
+	})
+
+	t.Run("Trigger while delayed", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clock := signalstest.NewFakeClock(t)
+
+		report, _ := signals.RunControls(context.Background(), func(ctx context.Context, c *signals.Controls) error {
+			c.Delay(time.Minute)
+			src.Send(syscall.SIGTERM)
+			clock.BlockUntil(1)
+			c.Trigger()
+			<-ctx.Done()
+			return nil
+		}, syscall.SIGTERM)
+		if report.Signal != nil {
+			t.Errorf("Expected no signal, got %v", report.Signal)
+		}
+	})
+
+	t.Run("Trigger", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+
+		report, _ := signals.RunControls(context.Background(), func(ctx context.Context, c *signals.Controls) error {
+			c.Trigger()
+			<-ctx.Done()
+			if cause := context.Cause(ctx); cause != signals.ErrTriggered {
+				t.Errorf("Expected ErrTriggered, got %v", cause)
+			}
+			return nil
+		}, syscall.SIGTERM)
+
+		if report.Signal != nil {
+			t.Errorf("Expected no signal, got %v", report.Signal)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// ErrHandoverTimeout is passed to Coordinator.OnHandoverCompleted when the
+// takeover was not acknowledged before the deadline.
+var ErrHandoverTimeout = errors.New("signals: handover not acknowledged before the deadline")
+
+// Lease is implemented by the lock or lease held by a stateful singleton.
+type Lease interface {
+	// Handover offers the lease to another replica and returns nil once the
+	// takeover is acknowledged. It must return when ctx is done.
+	Handover(ctx context.Context) error
+}
+
+// Coordinator interleaves signal handling with the handover of a Lease,
+// so that a stateful singleton keeps working until another replica has taken over.
+//
+// When a signal is received, the context returned by Context is not canceled
+// immediately: the Lease is handed over first, and the context is canceled once
+// the takeover is acknowledged or the Deadline passes.
+type Coordinator struct {
+	// Lease is handed over before the context is canceled.
+	// If nil, the context is canceled immediately.
+	Lease Lease
+
+	// Deadline bounds the wait for the takeover acknowledgment.
+	// Zero means no deadline.
+	Deadline time.Duration
+
+	// OnHandoverStarted, if not nil, is called when a signal starts the handover.
+	OnHandoverStarted func(sig os.Signal)
+
+	// OnHandoverCompleted, if not nil, is called when the handover completes,
+	// with nil if the takeover was acknowledged, ErrHandoverTimeout if the
+	// deadline passed, or the error returned by Lease.Handover.
+	OnHandoverCompleted func(err error)
+}
+
+// Context is like the function Context of this package, except that on receipt
+// of a signal the context is canceled only after the handover of the Lease completes.
+func (c *Coordinator) Context(parent context.Context, signals ...os.Signal) (ctx context.Context, stop func()) {
+	st := &state{policy: (&config{signals: signals}).policy()}
+	parent = context.WithValue(parent, stateKey{}, st)
+	ctx, cancel := context.WithCancelCause(parent)
+	if ctx.Err() != nil {
+		return ctx, func() { cancel(nil) }
+	}
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, signals...)
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			source.Stop(ch)
+			cancel(nil)
+		})
+	}
+	go func() {
+		select {
+		case sig := <-ch:
+			// The shutdown is measured from the receipt of sig, not from the
+			// end of the handover.
+			received := clock.Now()
+			c.handover(ctx, sig)
+			st.cancelAt(ctx, cancel, sig, received)
+		case <-ctx.Done():
+		}
+		source.Stop(ch)
+	}()
+	return ctx, stop
+}
+
+func (c *Coordinator) handover(ctx context.Context, sig os.Signal) {
+	if c.OnHandoverStarted != nil {
+		c.OnHandoverStarted(sig)
+	}
+	var err error
+	if c.Lease != nil {
+		err = c.handoverLease(ctx)
+	}
+	if c.OnHandoverCompleted != nil {
+		c.OnHandoverCompleted(err)
+	}
+}
+
+func (c *Coordinator) handoverLease(ctx context.Context) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	if c.Deadline > 0 {
+		timer := clock.NewTimer(c.Deadline)
+		defer timer.Stop()
+		go func() {
+			select {
+			case <-timer.C():
+				cancel(ErrHandoverTimeout)
+			case <-ctx.Done():
+			}
+		}()
+	}
+	err := c.Lease.Handover(ctx)
+	if cause := context.Cause(ctx); err != nil && cause == ErrHandoverTimeout {
+		return ErrHandoverTimeout
+	}
+	return err
+}
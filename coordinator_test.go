@@ -0,0 +1,84 @@
+package signals_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+type leaseFunc func(ctx context.Context) error
+
+func (f leaseFunc) Handover(ctx context.Context) error { return f(ctx) }
+
+func TestCoordinator(t *testing.T) {
+	t.Run("Acknowledged", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ack := make(chan struct{})
+		started := make(chan os.Signal, 1)
+		completed := make(chan error, 1)
+		c := &signals.Coordinator{
+			Lease: leaseFunc(func(ctx context.Context) error {
+				<-ack
+				return nil
+			}),
+			Deadline:            time.Minute,
+			OnHandoverStarted:   func(sig os.Signal) { started <- sig },
+			OnHandoverCompleted: func(err error) { completed <- err },
+		}
+		ctx, stop := c.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+
+		src.Send(syscall.SIGTERM)
+		if sig := <-started; sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", sig)
+		}
+		if err := ctx.Err(); err != nil {
+			t.Errorf("Expected the context not to be canceled during the handover, got %v", err)
+		}
+
+		close(ack)
+		if err := <-completed; err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clock := signalstest.NewFakeClock(t)
+		completed := make(chan error, 1)
+		c := &signals.Coordinator{
+			Lease: leaseFunc(func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			}),
+			Deadline:            10 * time.Second,
+			OnHandoverCompleted: func(err error) { completed <- err },
+		}
+		ctx, stop := c.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+
+		src.Send(syscall.SIGTERM)
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Second)
+		if err := <-completed; err != signals.ErrHandoverTimeout {
+			t.Errorf("Expected ErrHandoverTimeout, got %v", err)
+		}
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+	})
+
+	t.Run("No lease", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		var c signals.Coordinator
+		ctx, stop := c.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+	})
+}
@@ -0,0 +1,58 @@
+package signals
+
+import (
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// CountdownInterval is the interval between the calls made by WithCountdown.
+const CountdownInterval = time.Second
+
+// WithCountdown makes NewContext call f with the time remaining in the grace
+// period, as reported by Budget, when the signal is received and then every
+// CountdownInterval, until the grace period has elapsed or stop is called.
+// The last call is made with a remaining time of 0.
+//
+// This lets a CLI print "shutting down, 10s remaining, Ctrl-C again to force",
+// or a server log the progress of a long drain. The calls are made from a
+// single goroutine.
+func WithCountdown(f func(remaining time.Duration)) Option {
+	return func(c *config) {
+		c.countdown = f
+	}
+}
+
+func countdown(f func(time.Duration), st *state, released <-chan struct{}) {
+	received := st.receivedAt()
+	remaining := func() time.Duration {
+		if d := st.grace() - clock.Since(received); d > 0 {
+			return d
+		}
+		return 0
+	}
+	d := remaining()
+	f(d)
+	if d == 0 {
+		return
+	}
+	ticker := clock.NewTicker(CountdownInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+		case <-released:
+			return
+		}
+		select {
+		case <-released:
+			return
+		default:
+		}
+		d := remaining()
+		f(d)
+		if d == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestWithCountdown(t *testing.T) {
+	t.Run("elapsed", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		calls := make(chan time.Duration, 8)
+		_, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithGracePeriod(3*time.Second),
+			signals.WithCountdown(func(remaining time.Duration) { calls <- remaining }),
+		)
+		defer stop()
+		src.Send(syscall.SIGTERM)
+		for _, want := range []time.Duration{3 * time.Second, 2 * time.Second, time.Second, 0} {
+			if want != 3*time.Second {
+				clk.BlockUntil(1)
+				clk.Advance(signals.CountdownInterval)
+			}
+			if got := <-calls; got != want {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		calls := make(chan time.Duration, 8)
+		_, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithGracePeriod(3*time.Second),
+			signals.WithCountdown(func(remaining time.Duration) { calls <- remaining }),
+		)
+		src.Send(syscall.SIGTERM)
+		<-calls
+		clk.BlockUntil(1)
+		stop()
+		clk.Advance(signals.CountdownInterval)
+		select {
+		case got := <-calls:
+			t.Errorf("Expected no call after stop, got %v", got)
+		default:
+		}
+	})
+}
@@ -0,0 +1,14 @@
+package signals
+
+import "context"
+
+// Detach returns a context that carries the values of ctx but is never canceled,
+// even after ctx is canceled.
+//
+// Values include the signal recorded by Context, so FromContext reports the same
+// signal for the detached context as for ctx. This is useful for cleanup code
+// that must outlive the canceled context, such as flushing telemetry after SIGTERM.
+// Use context.WithTimeout on the result to bound such cleanup.
+func Detach(ctx context.Context) context.Context {
+	return context.WithoutCancel(ctx)
+}
@@ -0,0 +1,58 @@
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestDetach(t *testing.T) {
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		type key struct{}
+		parent := context.WithValue(context.Background(), key{}, "value")
+		ctx, stop := signals.Context(parent, syscall.SIGTERM)
+		defer stop()
+
+		detached := signals.Detach(ctx)
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+
+		if err := detached.Err(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		if cause := context.Cause(detached); cause != nil {
+			t.Errorf("Expected nil cause, got %v", cause)
+		}
+		if sig, ok := signals.FromContext(detached); !ok || sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", sig)
+		}
+		if v := detached.Value(key{}); v != "value" {
+			t.Errorf("Expected value, got %v", v)
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		detached := signals.Detach(ctx)
+		if _, ok := detached.Deadline(); ok {
+			t.Error("Expected no deadline")
+		}
+
+		child, cancelChild := context.WithCancel(detached)
+		cancel()
+		if err := child.Err(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		cancelChild()
+		if err := child.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package signals
+
+import (
+	"os"
+	"strings"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// Duplicate describes a signal that is registered from several call sites at
+// the same time, as reported by Diagnose.
+type Duplicate struct {
+	// Signal is the signal registered more than once,
+	// or nil if all of the registrations are for all signals.
+	Signal os.Signal
+
+	// Sites are the call sites of the registrations, formatted as
+	// "function (file:line)", the newest registration last.
+	Sites []string
+}
+
+// String returns a description of d suitable for logging.
+func (d Duplicate) String() string {
+	sig := "all signals"
+	if d.Signal != nil {
+		sig = d.Signal.String()
+	}
+	return "signals: " + sig + " registered by " + strings.Join(d.Sites, ", ")
+}
+
+// Diagnose enables the detection of duplicate registrations, and returns a
+// function that disables it. Until then, report is called from the registering
+// goroutine whenever a signal is registered while a registration of the same
+// signal made from another call site is live.
+//
+// The call site of a registration is the first caller outside this module,
+// such as the caller of Wait or Context. Registrations made from the same call
+// site, such as one Context per request, are not reported.
+//
+// Only the registrations made through this package are seen; the ones made
+// directly with package os/signal cannot be detected.
+// Diagnose is meant for debugging, since it captures a stack trace per registration.
+func Diagnose(report func(Duplicate)) (disable func()) {
+	return source.Diagnose(func(sig os.Signal, sites []string) {
+		report(Duplicate{Signal: sig, Sites: sites})
+	})
+}
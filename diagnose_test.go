@@ -0,0 +1,76 @@
+package signals_test
+
+import (
+	"context"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestDiagnose(t *testing.T) {
+	signalstest.NewFakeSource(t)
+	var got []signals.Duplicate
+	disable := signals.Diagnose(func(d signals.Duplicate) {
+		got = append(got, d)
+	})
+	defer disable()
+
+	_, stop1 := signals.Context(context.Background(), syscall.SIGINT, signals.Hangup)
+	defer stop1()
+	for i := 0; i < 2; i++ {
+		_, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+	}
+	if len(got) != 0 {
+		t.Fatalf("Expected no duplicate, got %v", got)
+	}
+
+	_, stop2 := signals.Context(context.Background(), signals.Hangup)
+	defer stop2()
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 duplicate, got %v", got)
+	}
+	if got[0].Signal != signals.Hangup {
+		t.Errorf("Expected %v, got %v", signals.Hangup, got[0].Signal)
+	}
+	if len(got[0].Sites) != 2 {
+		t.Fatalf("Expected 2 sites, got %v", got[0].Sites)
+	}
+	for _, site := range got[0].Sites {
+		if !strings.HasPrefix(site, "github.com/goaux/signals_test.TestDiagnose") {
+			t.Errorf("Expected the site in TestDiagnose, got %q", site)
+		}
+	}
+	if got[0].Sites[0] == got[0].Sites[1] {
+		t.Errorf("Expected different sites, got %v", got[0].Sites)
+	}
+
+	t.Run("all", func(t *testing.T) {
+		got = nil
+		_, stop := signals.Context(context.Background())
+		defer stop()
+		if len(got) != 3 {
+			t.Fatalf("Expected 3 duplicates, got %v", got)
+		}
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		disable()
+		got = nil
+		_, stop := signals.Context(context.Background(), syscall.SIGINT)
+		defer stop()
+		if len(got) != 0 {
+			t.Errorf("Expected no duplicate, got %v", got)
+		}
+	})
+}
+
+func TestDuplicateString(t *testing.T) {
+	d := signals.Duplicate{Sites: []string{"a", "b"}}
+	if s, want := d.String(), "signals: all signals registered by a, b"; s != want {
+		t.Errorf("Expected %q, got %q", want, s)
+	}
+}
@@ -0,0 +1,84 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// DrainOption configures DrainChannel.
+type DrainOption func(*drainConfig)
+
+type drainConfig struct {
+	timeout time.Duration
+}
+
+// DrainTimeout bounds the time DrainChannel spends consuming the buffered
+// items once ctx is done, instead of the Budget of ctx.
+func DrainTimeout(d time.Duration) DrainOption {
+	return func(c *drainConfig) {
+		c.timeout = d
+	}
+}
+
+// DrainChannel consumes the items of ch with handle until ch is closed or ctx is done.
+//
+// Once ctx is done, such as by a signal, DrainChannel keeps consuming the items
+// already buffered in ch, without waiting for new ones, until ch is empty or
+// the time reported by Budget for ctx has elapsed, and returns the number of
+// items left in ch, which are abandoned.
+//
+// The errors returned by handle do not stop the consumption;
+// DrainChannel returns them joined with errors.Join.
+func DrainChannel[T any](ctx context.Context, ch <-chan T, handle func(T) error, options ...DrainOption) (abandoned int, err error) {
+	cfg := drainConfig{timeout: -1}
+	for _, o := range options {
+		o(&cfg)
+	}
+	var errs []error
+	consume := func(item T) {
+		if err := handle(item); err != nil {
+			errs = append(errs, err)
+		}
+	}
+consume:
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return 0, errors.Join(errs...)
+			}
+			consume(item)
+		case <-ctx.Done():
+			break consume
+		}
+	}
+
+	timeout := cfg.timeout
+	if timeout < 0 {
+		timeout = Budget(ctx)
+	}
+	timer := clock.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C():
+			return len(ch), errors.Join(errs...)
+		default:
+		}
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return 0, errors.Join(errs...)
+			}
+			consume(item)
+		default:
+			return 0, errors.Join(errs...)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestDrainChannel(t *testing.T) {
+	t.Run("closed", func(t *testing.T) {
+		ch := make(chan int, 3)
+		ch <- 1
+		ch <- 2
+		close(ch)
+		sum := 0
+		abandoned, err := signals.DrainChannel(context.Background(), ch, func(n int) error {
+			sum += n
+			return nil
+		})
+		if abandoned != 0 || err != nil {
+			t.Errorf("Expected 0 and nil, got %d and %v", abandoned, err)
+		}
+		if sum != 3 {
+			t.Errorf("Expected 3, got %d", sum)
+		}
+	})
+
+	t.Run("signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		ch := make(chan int, 8)
+		errOdd := errors.New("odd")
+		var handled []int
+		ch <- 1
+		abandoned, err := signals.DrainChannel(ctx, ch, func(n int) error {
+			handled = append(handled, n)
+			if n == 1 {
+				for i := 2; i <= 4; i++ {
+					ch <- i
+				}
+				src.Send(syscall.SIGTERM)
+				<-ctx.Done()
+			}
+			if n%2 == 1 {
+				return errOdd
+			}
+			return nil
+		})
+		if abandoned != 0 {
+			t.Errorf("Expected 0 abandoned, got %d", abandoned)
+		}
+		if !errors.Is(err, errOdd) {
+			t.Errorf("Expected %v, got %v", errOdd, err)
+		}
+		if len(handled) != 4 {
+			t.Errorf("Expected 4 items handled, got %v", handled)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		ch := make(chan int, 8)
+		for i := 0; i < 5; i++ {
+			ch <- i
+		}
+		handled := 0
+		abandoned, err := signals.DrainChannel(ctx, ch, func(int) error {
+			handled++
+			if handled == 2 {
+				clk.Advance(time.Second)
+			}
+			return nil
+		}, signals.DrainTimeout(time.Second))
+		if err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		if handled != 2 || abandoned != 3 {
+			t.Errorf("Expected 2 handled and 3 abandoned, got %d and %d", handled, abandoned)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package signals
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// Drainer accounts for the in-flight jobs of a worker pool, similar to sync.WaitGroup,
+// so that the pool can be drained gracefully on shutdown:
+// new jobs are refused, in-flight jobs are waited for up to a deadline,
+// and the jobs still in flight after the deadline are reported as abandoned.
+//
+// The zero value is ready to use. A Drainer must not be copied after first use.
+type Drainer struct {
+	// OnAbandoned, if not nil, is called by Drain with the sorted IDs of
+	// the jobs still in flight when the timeout expired.
+	OnAbandoned func(ids []string)
+
+	mu       sync.Mutex
+	jobs     map[string]struct{}
+	draining bool
+	idle     chan struct{} // closed when jobs becomes empty while draining
+}
+
+// Add registers the job id as in flight and reports whether it was accepted.
+// Once Drain has been called, jobs are refused and Add returns false.
+//
+// The ids of the jobs in flight must be unique: Add panics if id is already in
+// flight, since the first job would otherwise not be waited for.
+func (d *Drainer) Add(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	if _, ok := d.jobs[id]; ok {
+		panic("signals: duplicate job " + strconv.Quote(id) + " added to Drainer")
+	}
+	if d.jobs == nil {
+		d.jobs = make(map[string]struct{})
+	}
+	d.jobs[id] = struct{}{}
+	return true
+}
+
+// Done marks the job id as finished.
+func (d *Drainer) Done(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.jobs, id)
+	if d.draining && len(d.jobs) == 0 && d.idle != nil {
+		close(d.idle)
+		d.idle = nil
+	}
+}
+
+// InFlight returns the number of jobs in flight.
+func (d *Drainer) InFlight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.jobs)
+}
+
+// Drain stops accepting new jobs and waits for the jobs in flight to finish,
+// up to timeout. A timeout of zero or less means no timeout.
+//
+// It returns the sorted IDs of the jobs still in flight when the timeout expired,
+// after passing them to OnAbandoned, or nil if all jobs finished.
+func (d *Drainer) Drain(timeout time.Duration) []string {
+	d.mu.Lock()
+	d.draining = true
+	if len(d.jobs) == 0 {
+		d.mu.Unlock()
+		return nil
+	}
+	if d.idle == nil {
+		d.idle = make(chan struct{})
+	}
+	idle := d.idle
+	d.mu.Unlock()
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := clock.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C()
+	}
+	select {
+	case <-idle:
+		return nil
+	case <-expired:
+	}
+
+	d.mu.Lock()
+	ids := make([]string, 0, len(d.jobs))
+	for id := range d.jobs {
+		ids = append(ids, id)
+	}
+	d.mu.Unlock()
+	if len(ids) == 0 {
+		return nil
+	}
+	sort.Strings(ids)
+	if d.OnAbandoned != nil {
+		d.OnAbandoned(ids)
+	}
+	return ids
+}
+
+// DrainOnDone blocks until ctx is done, typically canceled by a signal, then calls Drain with timeout.
+func (d *Drainer) DrainOnDone(ctx context.Context, timeout time.Duration) []string {
+	<-ctx.Done()
+	return d.Drain(timeout)
+}
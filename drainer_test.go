@@ -0,0 +1,98 @@
+package signals_test
+
+import (
+	"context"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestDrainer(t *testing.T) {
+	t.Run("All jobs finished", func(t *testing.T) {
+		var d signals.Drainer
+		d.Add("a")
+		d.Add("b")
+		go func() {
+			d.Done("a")
+			d.Done("b")
+		}()
+
+		if ids := d.Drain(0); ids != nil {
+			t.Errorf("Expected nil, got %v", ids)
+		}
+		if d.Add("c") {
+			t.Error("Expected Add to refuse jobs while draining")
+		}
+	})
+
+	t.Run("Duplicate job", func(t *testing.T) {
+		var d signals.Drainer
+		d.Add("a")
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected Add to panic on a duplicate job")
+			}
+		}()
+		d.Add("a")
+	})
+
+	t.Run("Job added again once done", func(t *testing.T) {
+		var d signals.Drainer
+		d.Add("a")
+		d.Done("a")
+		if !d.Add("a") {
+			t.Error("Expected Add to accept the job again")
+		}
+		if n := d.InFlight(); n != 1 {
+			t.Errorf("Expected 1, got %d", n)
+		}
+	})
+
+	t.Run("Abandoned", func(t *testing.T) {
+		clock := signalstest.NewFakeClock(t)
+		var reported []string
+		d := &signals.Drainer{OnAbandoned: func(ids []string) { reported = ids }}
+		d.Add("b")
+		d.Add("a")
+		d.Add("c")
+		d.Done("c")
+
+		done := make(chan []string, 1)
+		go func() { done <- d.Drain(10 * time.Second) }()
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Second)
+
+		want := []string{"a", "b"}
+		if ids := <-done; !reflect.DeepEqual(ids, want) {
+			t.Errorf("Expected %v, got %v", want, ids)
+		}
+		if !reflect.DeepEqual(reported, want) {
+			t.Errorf("Expected %v to be reported, got %v", want, reported)
+		}
+		if n := d.InFlight(); n != 2 {
+			t.Errorf("Expected 2, got %d", n)
+		}
+	})
+
+	t.Run("DrainOnDone", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+
+		var d signals.Drainer
+		d.Add("job")
+		go func() {
+			<-ctx.Done()
+			d.Done("job")
+		}()
+
+		src.Send(syscall.SIGTERM)
+		if ids := d.DrainOnDone(ctx, 0); ids != nil {
+			t.Errorf("Expected nil, got %v", ids)
+		}
+	})
+}
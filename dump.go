@@ -0,0 +1,114 @@
+package signals
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// Dumper is a component that can write a description of its state for diagnostics.
+//
+// If a Dumper also has a method Name() string, the name is used in the header
+// of its dump; otherwise its type is.
+type Dumper interface {
+	Dump(w io.Writer) error
+}
+
+// DumperFunc adapts a function to a Dumper.
+type DumperFunc func(w io.Writer) error
+
+// Dump calls f(w).
+func (f DumperFunc) Dump(w io.Writer) error {
+	return f(w)
+}
+
+// NamedDumper returns a Dumper with the given name, calling f.
+func NamedDumper(name string, f func(w io.Writer) error) Dumper {
+	return namedDumper{name: name, f: f}
+}
+
+type namedDumper struct {
+	name string
+	f    func(w io.Writer) error
+}
+
+func (d namedDumper) Name() string           { return d.name }
+func (d namedDumper) Dump(w io.Writer) error { return d.f(w) }
+
+// DumpState writes the dumps of dumpers to os.Stderr each time sig is
+// received, until ctx is done. It is equivalent to DumpStateTo(ctx, os.Stderr, sig, dumpers...).
+func DumpState(ctx context.Context, sig os.Signal, dumpers ...Dumper) {
+	DumpStateTo(ctx, os.Stderr, sig, dumpers...)
+}
+
+// DumpStateTo writes the dumps of dumpers to w each time sig is received,
+// until ctx is done, giving a service a consistent "kill -USR1" for diagnostics.
+//
+// The dumps are written one after another, each between a header with the name
+// of its Dumper and a footer with the time it took, or its error. A failing
+// Dumper does not prevent the others from being dumped. Since the dumps are
+// written from a single goroutine, they never interleave.
+func DumpStateTo(ctx context.Context, w io.Writer, sig os.Signal, dumpers ...Dumper) {
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, sig)
+	defer source.Stop(ch)
+	for {
+		select {
+		case sig := <-ch:
+			dumpState(w, sig, dumpers)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func dumpState(w io.Writer, sig os.Signal, dumpers []Dumper) {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+	started := clock.Now()
+	fmt.Fprintf(bw, "=== signals: state dump on %v at %s\n", sig, started.Format(time.RFC3339Nano))
+	for _, d := range dumpers {
+		name := dumperName(d)
+		fmt.Fprintf(bw, "--- %s\n", name)
+		begin := clock.Now()
+		lw := &lineWriter{w: bw, last: '\n'}
+		err := d.Dump(lw)
+		if lw.last != '\n' {
+			bw.WriteByte('\n')
+		}
+		if err != nil {
+			fmt.Fprintf(bw, "--- %s: error after %v: %v\n", name, clock.Since(begin), err)
+		} else {
+			fmt.Fprintf(bw, "--- %s: done in %v\n", name, clock.Since(begin))
+		}
+	}
+	fmt.Fprintf(bw, "=== signals: state dump done in %v\n", clock.Since(started))
+}
+
+func dumperName(d Dumper) string {
+	if n, ok := d.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T", d)
+}
+
+// lineWriter records the last byte written to w,
+// so that a footer can start on a line of its own.
+type lineWriter struct {
+	w    io.Writer
+	last byte
+}
+
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	n, err := lw.w.Write(p)
+	if n > 0 {
+		lw.last = p[n-1]
+	}
+	return n, err
+}
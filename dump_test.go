@@ -0,0 +1,87 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+type cache struct{ entries int }
+
+func (c *cache) Dump(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "entries: %d\n", c.entries)
+	return err
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDumpStateTo(t *testing.T) {
+	signalstest.NewFakeClock(t)
+	src := signalstest.NewFakeSource(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	var out syncBuffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		signals.DumpStateTo(ctx, &out, syscall.SIGUSR1,
+			&cache{entries: 3},
+			signals.NamedDumper("db", func(w io.Writer) error {
+				io.WriteString(w, "open: 2")
+				return errors.New("ping failed")
+			}),
+		)
+	}()
+	wait, cancelWait := context.WithTimeout(context.Background(), signalstest.AssertTimeout)
+	defer cancelWait()
+	if err := src.WaitSubscribed(wait, syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+	src.Send(syscall.SIGUSR1)
+	if !signalstest.Eventually(func() bool { return strings.Contains(out.String(), "state dump done") }) {
+		t.Fatalf("Expected a dump, got %q", out.String())
+	}
+	cancel()
+	<-done
+	src.AssertNotSubscribed(t, syscall.SIGUSR1)
+
+	pattern := `^=== signals: state dump on user defined signal 1 at \S+
+--- \*signals_test.cache
+entries: 3
+--- \*signals_test.cache: done in 0s
+--- db
+open: 2
+--- db: error after 0s: ping failed
+=== signals: state dump done in 0s
+$`
+	if got := out.String(); !regexp.MustCompile(pattern).MatchString(got) {
+		t.Errorf("Expected a dump matching %q, got %q", pattern, got)
+	}
+}
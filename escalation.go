@@ -0,0 +1,128 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// Escalation is a time-based policy executed after the first signal received
+// by a context created by NewContext with WithEscalation.
+//
+// It encodes the "be patient, then get forceful" policy declaratively:
+//
+//	signals.Escalation{Steps: []signals.Step{
+//		{After: 10 * time.Second, Do: signals.CancelHard},
+//		{After: 20 * time.Second, Do: signals.DumpGoroutines},
+//		{After: 30 * time.Second, Do: signals.Exit(1)},
+//	}}
+//
+// Steps that are not yet due when stop is called are not executed.
+type Escalation struct {
+	Steps []Step
+}
+
+// Step is a step of an Escalation.
+type Step struct {
+	// After is the delay from the receipt of the first signal.
+	After time.Duration
+
+	// Do is the action executed.
+	Do Action
+}
+
+// Action is an action of a Step.
+// It is called with the context created by NewContext.
+type Action func(ctx context.Context)
+
+// CancelHard is an Action that cancels the context returned by HardContext.
+func CancelHard(ctx context.Context) {
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		sig, _ := st.get()
+		st.cancelHard(Canceled{Signal: sig})
+	}
+}
+
+// DumpGoroutines is an Action that writes the stacks of all goroutines to os.Stderr.
+func DumpGoroutines(ctx context.Context) {
+	DumpGoroutinesTo(os.Stderr)(ctx)
+}
+
+// DumpGoroutinesTo returns an Action that writes the stacks of all goroutines to w.
+func DumpGoroutinesTo(w io.Writer) Action {
+	return func(context.Context) {
+		buf := make([]byte, 1<<16)
+		for {
+			n := runtime.Stack(buf, true)
+			if n < len(buf) {
+				buf = buf[:n]
+				break
+			}
+			buf = make([]byte, 2*len(buf))
+		}
+		fmt.Fprintf(w, "signals: goroutine dump\n%s\n", buf)
+	}
+}
+
+// Exit returns an Action that terminates the process with the given status code.
+func Exit(code int) Action {
+	return func(context.Context) {
+		os.Exit(code)
+	}
+}
+
+// WithEscalation executes e after the first signal received by the context.
+func WithEscalation(e Escalation) Option {
+	return func(c *config) {
+		c.escalation = &e
+	}
+}
+
+// HardContext returns the hard context of the context created by NewContext
+// from which ctx derives, or ctx itself if there is none.
+//
+// The hard context is not canceled by signals; it is canceled by the CancelHard
+// action of an Escalation, when stop is called, or when the parent is done.
+// Use it for operations that should be allowed to finish gracefully after the
+// first signal, but not indefinitely.
+func HardContext(ctx context.Context) context.Context {
+	if st, ok := ctx.Value(stateKey{}).(*state); ok && st.hard != nil {
+		return st.hard
+	}
+	return ctx
+}
+
+// escalate executes the steps of e, delayed by the extensions granted to st.
+func escalate(ctx context.Context, e *Escalation, st *state, released <-chan struct{}) {
+	received := st.receivedAt()
+	steps := append([]Step(nil), e.Steps...)
+	sort.SliceStable(steps, func(i, j int) bool { return steps[i].After < steps[j].After })
+	for _, step := range steps {
+	wait:
+		for {
+			extended, changed := st.extension()
+			timer := clock.NewTimer(step.After + extended - clock.Since(received))
+			select {
+			case <-timer.C():
+				break wait
+			case <-changed:
+				timer.Stop()
+			case <-released:
+				timer.Stop()
+				return
+			}
+		}
+		select {
+		case <-released:
+			return
+		default:
+		}
+		step.Do(ctx)
+	}
+}
@@ -0,0 +1,89 @@
+package signals_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestEscalation(t *testing.T) {
+	t.Run("Steps", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clock := signalstest.NewFakeClock(t)
+		var dump bytes.Buffer
+		steps := make(chan string, 2)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithEscalation(signals.Escalation{Steps: []signals.Step{
+				{After: 20 * time.Second, Do: func(ctx context.Context) {
+					signals.DumpGoroutinesTo(&dump)(ctx)
+					steps <- "dump"
+				}},
+				{After: 10 * time.Second, Do: func(ctx context.Context) {
+					signals.CancelHard(ctx)
+					steps <- "hard"
+				}},
+			}}),
+		)
+		defer stop()
+		hard := signals.HardContext(ctx)
+
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+		if err := hard.Err(); err != nil {
+			t.Fatalf("Expected the hard context not to be canceled, got %v", err)
+		}
+
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Second)
+		if step := <-steps; step != "hard" {
+			t.Errorf("Expected hard, got %v", step)
+		}
+		signalstest.AssertCanceledBy(t, hard, syscall.SIGTERM)
+
+		clock.BlockUntil(1)
+		clock.Advance(10 * time.Second)
+		if step := <-steps; step != "dump" {
+			t.Errorf("Expected dump, got %v", step)
+		}
+		if !strings.Contains(dump.String(), "goroutine") {
+			t.Errorf("Expected a goroutine dump, got %q", dump.String())
+		}
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clock := signalstest.NewFakeClock(t)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithEscalation(signals.Escalation{Steps: []signals.Step{
+				{After: time.Second, Do: func(context.Context) {
+					t.Error("Expected the step not to run")
+				}},
+			}}),
+		)
+
+		src.Send(syscall.SIGTERM)
+		<-ctx.Done()
+		clock.BlockUntil(1)
+		stop()
+		clock.Advance(time.Minute)
+
+		if err := signals.HardContext(ctx).Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("No hard context", func(t *testing.T) {
+		ctx := context.Background()
+		if hard := signals.HardContext(ctx); hard != ctx {
+			t.Errorf("Expected ctx itself, got %v", hard)
+		}
+	})
+}
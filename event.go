@@ -0,0 +1,57 @@
+package signals
+
+import (
+	"strconv"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// Event is a pseudo-signal for a lifecycle event of a host application that
+// has no OS signal, such as a mobile app built with gomobile.
+//
+// Events can be passed wherever this package accepts signals, so that shared
+// code can use one shutdown path on mobile and server, for example:
+//
+//	ctx, stop := signals.Context(ctx, syscall.SIGTERM, signals.Terminating)
+//
+// Events are delivered only with Inject, typically from the lifecycle callbacks
+// of the host, and only to subscribers that list them explicitly; subscribing
+// to all signals does not include events.
+type Event int
+
+// Lifecycle events.
+const (
+	Backgrounded Event = iota + 1 // the app moved to the background
+	Foregrounded                  // the app returned to the foreground
+	Terminating                   // the app is about to be terminated
+	LowMemory                     // the host requests to release memory
+	Resumed                       // the process resumed from a pause, see MonitorPauses
+	BackgroundIO                  // the process used its terminal from a background job, see TrapJobControl
+)
+
+var eventNames = map[Event]string{
+	Backgrounded: "backgrounded",
+	Foregrounded: "foregrounded",
+	Terminating:  "terminating",
+	LowMemory:    "low memory",
+	Resumed:      "resumed",
+	BackgroundIO: "background i/o",
+}
+
+// String returns the name of e.
+func (e Event) String() string {
+	if name, ok := eventNames[e]; ok {
+		return name
+	}
+	return "event " + strconv.Itoa(int(e))
+}
+
+// Signal implements os.Signal.
+func (Event) Signal() {}
+
+// Inject delivers e to the subscribers of e, such as Wait and Context, and
+// returns the number of subscribers it was delivered to.
+// Like OS signals, e is missed by subscribers that are not ready to receive it.
+func Inject(e Event) int {
+	return source.Inject(e, false)
+}
@@ -0,0 +1,53 @@
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestInject(t *testing.T) {
+	t.Run("Context", func(t *testing.T) {
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM, signals.Terminating)
+		defer stop()
+
+		if n := signals.Inject(signals.Backgrounded); n != 0 {
+			t.Errorf("Expected no delivery, got %d", n)
+		}
+		if n := signals.Inject(signals.Terminating); n != 1 {
+			t.Errorf("Expected 1 delivery, got %d", n)
+		}
+		signalstest.AssertCanceledBy(t, ctx, signals.Terminating)
+	})
+
+	t.Run("Not included in all signals", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		got := make(chan any, 1)
+		go func() { got <- signals.Wait(ctx) }()
+		src.WaitSubscribed(ctx, syscall.SIGTERM)
+
+		if n := signals.Inject(signals.Terminating); n != 0 {
+			t.Errorf("Expected no delivery, got %d", n)
+		}
+		src.Send(syscall.SIGTERM)
+		if sig := <-got; sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", sig)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if s := signals.Terminating.String(); s != "terminating" {
+			t.Errorf("Expected terminating, got %q", s)
+		}
+		if s := signals.Event(100).String(); s != "event 100" {
+			t.Errorf("Expected event 100, got %q", s)
+		}
+	})
+}
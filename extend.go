@@ -0,0 +1,113 @@
+package signals
+
+import (
+	"context"
+	"time"
+)
+
+// WithMaxExtension allows Extend to extend the grace period, and the
+// escalation, of the context by at most d in total. Without it, Extend
+// refuses every extension.
+func WithMaxExtension(d time.Duration) Option {
+	return func(c *config) {
+		c.maxExtension = d
+	}
+}
+
+// Extension describes a decision of Extend, as reported to the function given
+// by WithOnExtend.
+type Extension struct {
+	Requested time.Duration // the extension requested
+	Granted   bool          // whether it was granted
+	Total     time.Duration // the total of the extensions granted so far
+	Max       time.Duration // the maximum given by WithMaxExtension
+	Grace     time.Duration // the grace period, including the extensions granted
+
+	// Deadline is the time by which the shutdown must be complete, as
+	// reported by ShutdownDeadline, or the zero time if no signal was received yet.
+	Deadline time.Time
+}
+
+// WithOnExtend makes Extend call f with each of its decisions on the
+// context, for example to log them with the new deadline.
+func WithOnExtend(f func(Extension)) Option {
+	return func(c *config) {
+		c.onExtend = f
+	}
+}
+
+// Extend requests d more time for the shutdown of the context created by
+// NewContext from which ctx derives, for cleanup code that needs longer than
+// planned, such as a long compaction. It reports whether the extension was
+// granted: the extensions granted to a context cannot exceed in total the
+// maximum given by WithMaxExtension.
+//
+// A granted extension lengthens the grace period reported by Budget and
+// ShutdownDeadline, and delays the steps of the escalation that are not yet
+// executed by d. It does not delay the forced termination by an external
+// supervisor, such as the kubelet, which only the configuration of the latter
+// can change. The decision is reported to the function given by WithOnExtend.
+func Extend(ctx context.Context, d time.Duration) bool {
+	st, ok := ctx.Value(stateKey{}).(*state)
+	if !ok || d <= 0 {
+		return false
+	}
+	st.mu.Lock()
+	granted := st.extended+d <= st.policy.MaxExtension
+	if granted {
+		st.extended += d
+		if st.extendedCh != nil {
+			close(st.extendedCh)
+		}
+		st.extendedCh = make(chan struct{})
+	}
+	e := Extension{
+		Requested: d,
+		Granted:   granted,
+		Total:     st.extended,
+		Max:       st.policy.MaxExtension,
+		Grace:     st.policy.Grace + st.extended,
+	}
+	if !st.received.IsZero() {
+		e.Deadline = st.received.Add(e.Grace)
+	}
+	st.mu.Unlock()
+
+	if st.onExtend != nil {
+		st.onExtend(e)
+	}
+	return granted
+}
+
+// ShutdownDeadline returns the time by which the shutdown of the context
+// created by NewContext from which ctx derives must be complete: the time its
+// signal was received plus its grace period, including the extensions granted
+// by Extend. It reports false if no signal was received yet.
+func ShutdownDeadline(ctx context.Context) (deadline time.Time, ok bool) {
+	st, ok := ctx.Value(stateKey{}).(*state)
+	if !ok {
+		return time.Time{}, false
+	}
+	received := st.receivedAt()
+	if received.IsZero() {
+		return time.Time{}, false
+	}
+	return received.Add(st.grace()), true
+}
+
+// extension returns the total of the extensions granted so far, and a channel
+// closed when another extension is granted.
+func (s *state) extension() (time.Duration, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.extendedCh == nil {
+		s.extendedCh = make(chan struct{})
+	}
+	return s.extended, s.extendedCh
+}
+
+// grace returns the grace period of s, including the extensions granted.
+func (s *state) grace() time.Duration {
+	extended, _ := s.extension()
+	return s.policy.Grace + extended
+}
@@ -0,0 +1,101 @@
+package signals_test
+
+import (
+	"context"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestExtend(t *testing.T) {
+	t.Run("Budget and deadline", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		var decisions []signals.Extension
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithGracePeriod(10*time.Second),
+			signals.WithMaxExtension(5*time.Second),
+			signals.WithOnExtend(func(e signals.Extension) { decisions = append(decisions, e) }),
+		)
+		defer stop()
+		if _, ok := signals.ShutdownDeadline(ctx); ok {
+			t.Error("Expected no deadline before the signal")
+		}
+
+		received := clk.Now()
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+		if !signals.Extend(ctx, 3*time.Second) {
+			t.Fatal("Expected the extension to be granted")
+		}
+		if signals.Extend(ctx, 3*time.Second) {
+			t.Error("Expected the extension beyond the maximum to be refused")
+		}
+		if !signals.Extend(ctx, 2*time.Second) {
+			t.Error("Expected the extension up to the maximum to be granted")
+		}
+		clk.Advance(time.Second)
+		if d := signals.Budget(ctx); d != 14*time.Second {
+			t.Errorf("Expected 14s, got %v", d)
+		}
+		if deadline, ok := signals.ShutdownDeadline(ctx); !ok || !deadline.Equal(received.Add(15*time.Second)) {
+			t.Errorf("Expected %v, got %v", received.Add(15*time.Second), deadline)
+		}
+		want := []signals.Extension{
+			{Requested: 3 * time.Second, Granted: true, Total: 3 * time.Second, Max: 5 * time.Second, Grace: 13 * time.Second, Deadline: received.Add(13 * time.Second)},
+			{Requested: 3 * time.Second, Total: 3 * time.Second, Max: 5 * time.Second, Grace: 13 * time.Second, Deadline: received.Add(13 * time.Second)},
+			{Requested: 2 * time.Second, Granted: true, Total: 5 * time.Second, Max: 5 * time.Second, Grace: 15 * time.Second, Deadline: received.Add(15 * time.Second)},
+		}
+		if !reflect.DeepEqual(decisions, want) {
+			t.Errorf("Expected %+v, got %+v", want, decisions)
+		}
+	})
+
+	t.Run("Escalation", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		steps := make(chan struct{}, 1)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGTERM),
+			signals.WithMaxExtension(time.Minute),
+			signals.WithEscalation(signals.Escalation{Steps: []signals.Step{
+				{After: 10 * time.Second, Do: func(context.Context) { steps <- struct{}{} }},
+			}}),
+		)
+		defer stop()
+		signals.Extend(ctx, 5*time.Second)
+
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+		clk.BlockUntil(1)
+		clk.Advance(10 * time.Second)
+		select {
+		case <-steps:
+			t.Fatal("Expected the step to be delayed")
+		case <-time.After(10 * time.Millisecond):
+		}
+		clk.Advance(5 * time.Second)
+		select {
+		case <-steps:
+		case <-time.After(signalstest.AssertTimeout):
+			t.Fatal("Expected the step to run")
+		}
+	})
+
+	t.Run("Refused", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		if signals.Extend(ctx, time.Second) {
+			t.Error("Expected the extension to be refused without WithMaxExtension")
+		}
+		if signals.Extend(context.Background(), time.Second) {
+			t.Error("Expected the extension to be refused without NewContext")
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// WithFilter makes NewContext ignore the signals for which f returns false,
+// for example SIGINT while a critical migration runs, while still canceling
+// on SIGTERM:
+//
+//	signals.WithFilter(func(sig os.Signal) bool {
+//		return sig != syscall.SIGINT || !migrating.Load()
+//	})
+//
+// It can be given multiple times; a signal must be accepted by every filter.
+// The filters are called from a single goroutine. The signals ignored are
+// consumed, so that their default behavior does not apply, and counted by
+// Filtered. With WithRequeue, they are retried later.
+func WithFilter(f func(sig os.Signal) bool) Option {
+	return func(c *config) {
+		c.filters = append(c.filters, f)
+	}
+}
+
+// WithRequeue makes NewContext keep the signals ignored by WithFilter, and
+// check them against the filters again every interval, so that, for example,
+// a SIGINT received during a migration cancels the context once the migration
+// is over. A signal is kept once however many times it is ignored.
+func WithRequeue(interval time.Duration) Option {
+	return func(c *config) {
+		c.requeue = interval
+	}
+}
+
+// Filtered returns the number of signals ignored by the filters of the
+// context created by NewContext from which ctx derives, including the retries
+// of WithRequeue.
+func Filtered(ctx context.Context) uint64 {
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		return st.filtered.Load()
+	}
+	return 0
+}
+
+func (c *config) accepts(sig os.Signal) bool {
+	for _, f := range c.filters {
+		if !f(sig) {
+			return false
+		}
+	}
+	return true
+}
+
+// filter relays the signals of in accepted by the filters of c to the returned
+// channel, until quit is closed. Like package os/signal, it drops the signals
+// that do not fit in the returned channel.
+func filter(c *config, st *state, in <-chan os.Signal, quit <-chan struct{}) <-chan os.Signal {
+	out := make(chan os.Signal, cap(in))
+	forward := func(sig os.Signal) {
+		select {
+		case out <- sig:
+		default:
+		}
+	}
+	go func() {
+		var (
+			pending []os.Signal
+			ticker  clock.Ticker
+			retry   <-chan time.Time
+		)
+		defer func() {
+			if ticker != nil {
+				ticker.Stop()
+			}
+		}()
+		for {
+			select {
+			case sig := <-in:
+				if c.accepts(sig) {
+					forward(sig)
+					continue
+				}
+				st.filtered.Add(1)
+				if c.requeue <= 0 || contains(pending, sig) {
+					continue
+				}
+				pending = append(pending, sig)
+				if ticker == nil {
+					ticker = clock.NewTicker(c.requeue)
+					retry = ticker.C()
+				}
+			case <-retry:
+				kept := pending[:0]
+				for _, sig := range pending {
+					if c.accepts(sig) {
+						forward(sig)
+					} else {
+						st.filtered.Add(1)
+						kept = append(kept, sig)
+					}
+				}
+				pending = kept
+				if len(pending) == 0 {
+					ticker.Stop()
+					ticker, retry = nil, nil
+				}
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func contains(sigs []os.Signal, sig os.Signal) bool {
+	for _, s := range sigs {
+		if s == sig {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package signals_test
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestWithFilter(t *testing.T) {
+	t.Run("Ignored", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		var migrating atomic.Bool
+		migrating.Store(true)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGINT, syscall.SIGTERM),
+			signals.WithFilter(func(sig os.Signal) bool {
+				return sig != syscall.SIGINT || !migrating.Load()
+			}),
+		)
+		defer stop()
+
+		src.Send(syscall.SIGINT)
+		signalstest.Eventually(func() bool { return signals.Filtered(ctx) != 0 })
+		if n := signals.Filtered(ctx); n != 1 {
+			t.Errorf("Expected 1 filtered, got %d", n)
+		}
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("Expected SIGINT to be ignored, got %v", err)
+		}
+
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+	})
+
+	t.Run("Composed", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGINT, syscall.SIGTERM, signals.Hangup),
+			signals.WithFilter(func(sig os.Signal) bool { return sig != syscall.SIGINT }),
+			signals.WithFilter(func(sig os.Signal) bool { return sig != signals.Hangup }),
+		)
+		defer stop()
+
+		src.Send(signals.Hangup)
+		src.Send(syscall.SIGINT)
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+	})
+
+	t.Run("WithRequeue", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		var migrating atomic.Bool
+		migrating.Store(true)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGINT),
+			signals.WithFilter(func(os.Signal) bool { return !migrating.Load() }),
+			signals.WithRequeue(time.Second),
+		)
+		defer stop()
+
+		src.Send(syscall.SIGINT)
+		clk.BlockUntil(1)
+		clk.Advance(time.Second)
+		if !signalstest.Eventually(func() bool { return signals.Filtered(ctx) >= 2 }) {
+			t.Fatalf("Expected 2 filtered, got %d", signals.Filtered(ctx))
+		}
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("Expected SIGINT to be kept, got %v", err)
+		}
+
+		migrating.Store(false)
+		clk.Advance(time.Second)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGINT)
+	})
+}
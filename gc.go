@@ -0,0 +1,116 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// GCSettings are garbage collector settings applied by ToggleGC.
+type GCSettings struct {
+	// Percent is the GOGC value to set, as by debug.SetGCPercent.
+	// A negative value turns the garbage collector off; zero leaves GOGC unchanged.
+	Percent int
+
+	// MemoryLimit is the GOMEMLIMIT value to set in bytes, as by debug.SetMemoryLimit.
+	// Zero leaves GOMEMLIMIT unchanged.
+	MemoryLimit int64
+}
+
+// GCState describes the effect of ToggleGC and FreeOSMemory, as reported by ReadGCState.
+type GCState struct {
+	// Toggled reports whether settings applied by ToggleGC are in effect.
+	Toggled bool
+
+	// Settings are the settings in effect if Toggled is true.
+	Settings GCSettings
+
+	// Freed is the number of times FreeOSMemory returned memory to the system,
+	// and LastFreed the time it last did.
+	Freed     int
+	LastFreed time.Time
+}
+
+var gc struct {
+	mu    sync.Mutex
+	state GCState
+}
+
+// ReadGCState returns the current GCState, for observability.
+func ReadGCState() GCState {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	return gc.state
+}
+
+// ToggleGC applies settings when sig is received, and restores the previous
+// settings when sig is received again, alternately, until ctx is done. The
+// previous settings are restored before ToggleGC returns. This gives operators
+// a lever against memory pressure without a restart.
+func ToggleGC(ctx context.Context, sig os.Signal, settings GCSettings) {
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, sig)
+	defer source.Stop(ch)
+	var prev *GCSettings
+	defer func() {
+		if prev != nil {
+			setGC(*prev, false)
+		}
+	}()
+	for {
+		select {
+		case <-ch:
+			if prev != nil {
+				setGC(*prev, false)
+				prev = nil
+				break
+			}
+			p := setGC(settings, true)
+			prev = &p
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// setGC applies settings and returns the settings they replaced.
+func setGC(settings GCSettings, toggled bool) (prev GCSettings) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	if settings.Percent != 0 {
+		prev.Percent = debug.SetGCPercent(settings.Percent)
+	}
+	if settings.MemoryLimit != 0 {
+		prev.MemoryLimit = debug.SetMemoryLimit(settings.MemoryLimit)
+	}
+	gc.state.Toggled = toggled
+	gc.state.Settings = GCSettings{}
+	if toggled {
+		gc.state.Settings = settings
+	}
+	return prev
+}
+
+// FreeOSMemory calls debug.FreeOSMemory each time sig is received, until ctx is done.
+func FreeOSMemory(ctx context.Context, sig os.Signal) {
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, sig)
+	defer source.Stop(ch)
+	for {
+		select {
+		case <-ch:
+			debug.FreeOSMemory()
+			gc.mu.Lock()
+			gc.state.Freed++
+			gc.state.LastFreed = clock.Now()
+			gc.mu.Unlock()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
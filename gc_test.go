@@ -0,0 +1,88 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"runtime/debug"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+// waitGCState waits until the GCState satisfies ok.
+func waitGCState(t *testing.T, ok func(signals.GCState) bool) {
+	t.Helper()
+	if !signalstest.Eventually(func() bool { return ok(signals.ReadGCState()) }) {
+		t.Fatalf("Unexpected state %+v", signals.ReadGCState())
+	}
+}
+
+func TestToggleGC(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	limit := debug.SetMemoryLimit(-1)
+	percent := debug.SetGCPercent(100)
+	defer debug.SetGCPercent(percent)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	settings := signals.GCSettings{Percent: 50, MemoryLimit: 1 << 40}
+	go func() {
+		defer close(done)
+		signals.ToggleGC(ctx, syscall.SIGUSR1, settings)
+	}()
+	wait, cancelWait := context.WithTimeout(context.Background(), signalstest.AssertTimeout)
+	defer cancelWait()
+	if err := src.WaitSubscribed(wait, syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	src.Send(syscall.SIGUSR1)
+	waitGCState(t, func(s signals.GCState) bool { return s.Toggled })
+	if s := signals.ReadGCState(); s.Settings != settings {
+		t.Errorf("Expected %+v, got %+v", settings, s.Settings)
+	}
+	if got := debug.SetMemoryLimit(-1); got != 1<<40 {
+		t.Errorf("Expected %d, got %d", int64(1<<40), got)
+	}
+
+	src.Send(syscall.SIGUSR1)
+	waitGCState(t, func(s signals.GCState) bool { return !s.Toggled })
+	if got := debug.SetMemoryLimit(-1); got != limit {
+		t.Errorf("Expected %d, got %d", limit, got)
+	}
+	if got := debug.SetGCPercent(100); got != 100 {
+		t.Errorf("Expected 100, got %d", got)
+	}
+
+	src.Send(syscall.SIGUSR1)
+	waitGCState(t, func(s signals.GCState) bool { return s.Toggled })
+	cancel()
+	<-done
+	if s := signals.ReadGCState(); s.Toggled {
+		t.Errorf("Expected the settings to be restored, got %+v", s)
+	}
+	if got := debug.SetMemoryLimit(-1); got != limit {
+		t.Errorf("Expected %d, got %d", limit, got)
+	}
+}
+
+func TestFreeOSMemory(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	freed := signals.ReadGCState().Freed
+	go signals.FreeOSMemory(ctx, syscall.SIGUSR2)
+	wait, cancelWait := context.WithTimeout(ctx, signalstest.AssertTimeout)
+	defer cancelWait()
+	if err := src.WaitSubscribed(wait, syscall.SIGUSR2); err != nil {
+		t.Fatal(err)
+	}
+	src.Send(syscall.SIGUSR2)
+	waitGCState(t, func(s signals.GCState) bool { return s.Freed == freed+1 })
+	if s := signals.ReadGCState(); s.LastFreed.IsZero() {
+		t.Error("Expected LastFreed to be set")
+	}
+}
@@ -0,0 +1,137 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Group is a collection of goroutines working on subtasks of a common task,
+// with the same semantics as golang.org/x/sync/errgroup.Group.
+//
+// A Group created by NewGroup additionally cancels its context when one of
+// the given signals is received, so that existing errgroup code can be
+// migrated by changing only the constructor.
+//
+// A zero Group is valid, has no limit on the number of active goroutines,
+// and does not cancel on error or signal.
+type Group struct {
+	cancel func(error)
+
+	wg sync.WaitGroup
+
+	sem chan struct{}
+
+	errOnce sync.Once
+	err     error
+}
+
+// NewGroup returns a new Group and an associated context derived from ctx.
+//
+// The derived context is canceled the first time a function passed to Go
+// returns a non-nil error, the first time Wait returns, or when one of the
+// specified signals is received, whichever occurs first.
+// When a signal is received, the cause of the cancellation is a Canceled
+// holding the signal.
+//
+// Unlike Wait, if no signals are provided, no signals are monitored.
+func NewGroup(ctx context.Context, signals ...os.Signal) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &Group{cancel: cancel}
+	if len(signals) > 0 {
+		go func() {
+			if sig := Wait(ctx, signals...); sig != nil {
+				cancel(Canceled{Signal: sig})
+			}
+		}()
+	}
+	return g, ctx
+}
+
+func (g *Group) done() {
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// Wait blocks until all function calls from the Go method have returned,
+// then returns the first non-nil error (if any) from them.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+	return g.err
+}
+
+// Go calls the given function in a new goroutine.
+// It blocks until the new goroutine can be added without the number of
+// active goroutines in the group exceeding the configured limit.
+//
+// The first call to return a non-nil error cancels the group's context, if the
+// group was created by calling NewGroup. The error will be returned by Wait.
+func (g *Group) Go(f func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := f(); err != nil {
+			g.setErr(err)
+		}
+	}()
+}
+
+// TryGo calls the given function in a new goroutine only if the number of
+// active goroutines in the group is currently below the configured limit.
+//
+// The return value reports whether the goroutine was started.
+func (g *Group) TryGo(f func() error) bool {
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+		if err := f(); err != nil {
+			g.setErr(err)
+		}
+	}()
+	return true
+}
+
+// SetLimit limits the number of active goroutines in this group to at most n.
+// A negative value indicates no limit.
+//
+// Any subsequent call to the Go method will block until it can add an active
+// goroutine without exceeding the configured limit.
+//
+// The limit must not be modified while any goroutines in the group are active.
+func (g *Group) SetLimit(n int) {
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic(fmt.Errorf("signals: modify limit while %v goroutines in the group are still active", len(g.sem)))
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+func (g *Group) setErr(err error) {
+	g.errOnce.Do(func() {
+		g.err = err
+		if g.cancel != nil {
+			g.cancel(g.err)
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("First error", func(t *testing.T) {
+		g, ctx := signals.NewGroup(context.Background(), syscall.SIGINT)
+		errFirst := errors.New("first")
+		g.Go(func() error { return errFirst })
+		g.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		if err := g.Wait(); err != errFirst {
+			t.Errorf("Expected %v, got %v", errFirst, err)
+		}
+		if cause := context.Cause(ctx); cause != errFirst {
+			t.Errorf("Expected cause %v, got %v", errFirst, cause)
+		}
+	})
+
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewOSSource(t)
+		g, ctx := signals.NewGroup(context.Background(), syscall.SIGINT)
+		g.Go(func() error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		go func() {
+			src.WaitSubscribed(ctx, syscall.SIGINT)
+			syscall.Kill(os.Getpid(), syscall.SIGINT)
+		}()
+
+		if err := g.Wait(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if sig, ok := signals.FromContext(ctx); !ok || sig != syscall.SIGINT {
+			t.Errorf("Expected %v, got %v", syscall.SIGINT, sig)
+		}
+	})
+
+	t.Run("Wait cancels context", func(t *testing.T) {
+		g, ctx := signals.NewGroup(context.Background())
+		g.Go(func() error { return nil })
+
+		if err := g.Wait(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("SetLimit", func(t *testing.T) {
+		var g signals.Group
+		g.SetLimit(2)
+		var active, peak atomic.Int32
+		for i := 0; i < 10; i++ {
+			g.Go(func() error {
+				n := active.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				active.Add(-1)
+				return nil
+			})
+		}
+		g.Wait()
+		if p := peak.Load(); p > 2 {
+			t.Errorf("Expected at most 2 active goroutines, got %d", p)
+		}
+	})
+
+	t.Run("TryGo", func(t *testing.T) {
+		var g signals.Group
+		g.SetLimit(1)
+		release := make(chan struct{})
+		if !g.TryGo(func() error { <-release; return nil }) {
+			t.Fatal("Expected the first TryGo to succeed")
+		}
+		if g.TryGo(func() error { return nil }) {
+			t.Error("Expected the second TryGo to fail")
+		}
+		close(release)
+		g.Wait()
+		if !g.TryGo(func() error { return nil }) {
+			t.Error("Expected TryGo to succeed after Wait")
+		}
+		g.Wait()
+	})
+}
@@ -0,0 +1,131 @@
+package signals
+
+import (
+	"os"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// Handlers calls functions registered per signal, each with Once or Recurring
+// semantics, so that callers need no unsubscribe bookkeeping of their own: a
+// signal is subscribed to while a function is registered for it, and
+// unsubscribed from as soon as none is left.
+//
+// The functions registered for a signal are called in the order of their
+// registration, from a goroutine dedicated to the signal. The zero value is
+// ready to use. A Handlers must not be copied after first use.
+type Handlers struct {
+	mu   sync.Mutex
+	sigs map[os.Signal]*handled
+}
+
+// handled is the subscription of Handlers to a signal.
+type handled struct {
+	ch   chan os.Signal
+	done chan struct{}
+	regs []*registration
+}
+
+type registration struct {
+	f    func(os.Signal)
+	once bool
+}
+
+// Once registers f to be called the next time sig is received, after which f
+// is unregistered. Calling remove unregisters f if it has not been called yet.
+func (h *Handlers) Once(sig os.Signal, f func(sig os.Signal)) (remove func()) {
+	return h.add(sig, &registration{f: f, once: true})
+}
+
+// Recurring registers f to be called each time sig is received, until remove is called.
+func (h *Handlers) Recurring(sig os.Signal, f func(sig os.Signal)) (remove func()) {
+	return h.add(sig, &registration{f: f})
+}
+
+func (h *Handlers) add(sig os.Signal, r *registration) (remove func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.sigs == nil {
+		h.sigs = make(map[os.Signal]*handled)
+	}
+	s, ok := h.sigs[sig]
+	if !ok {
+		s = &handled{ch: make(chan os.Signal, 1), done: make(chan struct{})}
+		h.sigs[sig] = s
+		source.Notify(s.ch, sig)
+		go h.listen(s)
+	}
+	s.regs = append(s.regs, r)
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.remove(sig, r)
+	}
+}
+
+func (h *Handlers) listen(s *handled) {
+	for {
+		select {
+		case sig := <-s.ch:
+			h.dispatch(s, sig)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// dispatch calls the functions of s registered for sig, unregistering the
+// ones registered with Once before they are called.
+func (h *Handlers) dispatch(s *handled, sig os.Signal) {
+	h.mu.Lock()
+	if h.sigs[sig] != s {
+		// s was unsubscribed while sig was pending.
+		h.mu.Unlock()
+		return
+	}
+	regs := append([]*registration(nil), s.regs...)
+	for _, r := range regs {
+		if r.once {
+			h.remove(sig, r)
+		}
+	}
+	h.mu.Unlock()
+	for _, r := range regs {
+		r.f(sig)
+	}
+}
+
+// remove unregisters r, and unsubscribes from sig if no registration is left.
+// It must be called with h.mu held.
+func (h *Handlers) remove(sig os.Signal, r *registration) {
+	s, ok := h.sigs[sig]
+	if !ok {
+		return
+	}
+	for i, v := range s.regs {
+		if v == r {
+			s.regs = append(s.regs[:i:i], s.regs[i+1:]...)
+			break
+		}
+	}
+	if len(s.regs) == 0 {
+		h.stop(sig, s)
+	}
+}
+
+// stop unsubscribes from sig. It must be called with h.mu held.
+func (h *Handlers) stop(sig os.Signal, s *handled) {
+	source.Stop(s.ch)
+	close(s.done)
+	delete(h.sigs, sig)
+}
+
+// Close unregisters all the functions and unsubscribes from all the signals.
+func (h *Handlers) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sig, s := range h.sigs {
+		h.stop(sig, s)
+	}
+}
@@ -0,0 +1,86 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestHandlers(t *testing.T) {
+	receive := func(t *testing.T, calls <-chan string, want string) {
+		t.Helper()
+		select {
+		case got := <-calls:
+			if got != want {
+				t.Errorf("Expected %s, got %s", want, got)
+			}
+		case <-time.After(signalstest.AssertTimeout):
+			t.Fatalf("Expected %s to be called", want)
+		}
+	}
+
+	t.Run("Once", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		checkLeaks(t)
+		var h signals.Handlers
+		calls := make(chan string, 4)
+		h.Once(syscall.SIGUSR1, func(os.Signal) { calls <- "once" })
+		src.AssertSubscribed(t, syscall.SIGUSR1)
+
+		src.Send(syscall.SIGUSR1)
+		receive(t, calls, "once")
+		ctx, cancel := context.WithTimeout(context.Background(), signalstest.AssertTimeout)
+		defer cancel()
+		if err := src.WaitNotSubscribed(ctx, syscall.SIGUSR1); err != nil {
+			t.Fatal(err)
+		}
+		if n := src.Send(syscall.SIGUSR1); n != 0 {
+			t.Errorf("Expected no subscriber, got %d", n)
+		}
+	})
+
+	t.Run("Recurring", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		checkLeaks(t)
+		var h signals.Handlers
+		calls := make(chan string, 4)
+		remove := h.Recurring(syscall.SIGHUP, func(os.Signal) { calls <- "recurring" })
+		h.Once(syscall.SIGHUP, func(os.Signal) { calls <- "once" })
+
+		src.Send(syscall.SIGHUP)
+		receive(t, calls, "recurring")
+		receive(t, calls, "once")
+		src.Send(syscall.SIGHUP)
+		receive(t, calls, "recurring")
+
+		remove()
+		src.AssertNotSubscribed(t, syscall.SIGHUP)
+	})
+
+	t.Run("Remove before firing", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		var h signals.Handlers
+		remove := h.Once(syscall.SIGUSR2, func(os.Signal) { t.Error("Expected f not to be called") })
+		remove()
+		remove()
+		src.AssertNotSubscribed(t, syscall.SIGUSR2)
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		checkLeaks(t)
+		var h signals.Handlers
+		h.Recurring(syscall.SIGHUP, func(os.Signal) {})
+		h.Once(syscall.SIGUSR1, func(os.Signal) {})
+		h.Close()
+		src.AssertNotSubscribed(t, syscall.SIGHUP)
+		src.AssertNotSubscribed(t, syscall.SIGUSR1)
+	})
+}
@@ -0,0 +1,64 @@
+package signals
+
+import (
+	"os"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// holdSize is the number of signals buffered by Hold.
+const holdSize = 64
+
+// Hold subscribes to the specified signals immediately and buffers them until
+// release is called, to be called first thing in main, before a slow
+// initialization:
+//
+//	func main() {
+//		release := signals.Hold(syscall.SIGINT, syscall.SIGTERM)
+//		// ... slow initialization ...
+//		ctx, stop := signals.Context(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+//		defer stop()
+//		release()
+//		// ...
+//	}
+//
+// A SIGTERM received during the initialization neither kills the process
+// before its handlers are installed, nor is lost: release unsubscribes and
+// replays the buffered signals, in order, to the subscribers installed
+// meanwhile, such as Context, Wait or Handlers, as if they had just been
+// received. A replayed OS signal that no subscriber is left to receive is
+// raised again, so that its default action applies. Up to 64 signals are
+// buffered; later ones are dropped.
+//
+// If no signals are provided, all incoming signals will be held.
+// It is safe to call release more than once.
+func Hold(signals ...os.Signal) (release func()) {
+	ch := make(chan os.Signal, holdSize)
+	source.Notify(ch, signals...)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			source.Stop(ch)
+			for {
+				select {
+				case sig := <-ch:
+					replay(sig)
+				default:
+					return
+				}
+			}
+		})
+	}
+}
+
+// replay delivers sig to its subscribers, or raises it again if there is none.
+func replay(sig os.Signal) {
+	_, event := sig.(Event)
+	if source.Inject(sig, !event) > 0 || event || source.Get() != (source.OS{}) {
+		return
+	}
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		p.Signal(sig)
+	}
+}
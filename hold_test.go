@@ -0,0 +1,50 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestHold(t *testing.T) {
+	t.Run("Replay", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		release := signals.Hold(syscall.SIGINT, syscall.SIGTERM)
+		src.AssertSubscribed(t, syscall.SIGTERM)
+		src.Send(syscall.SIGTERM)
+
+		ctx, stop := signals.Context(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("Expected the signal to be held, got %v", err)
+		}
+		release()
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+		release()
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		release := signals.Hold(syscall.SIGHUP, syscall.SIGUSR1)
+		src.Send(syscall.SIGHUP)
+		src.Send(syscall.SIGUSR1)
+		src.Send(syscall.SIGHUP)
+
+		sub := signals.Subscribe(4, syscall.SIGHUP, syscall.SIGUSR1)
+		defer sub.Close()
+		release()
+		for _, want := range []syscall.Signal{syscall.SIGHUP, syscall.SIGUSR1, syscall.SIGHUP} {
+			if got := <-sub.C; got != want {
+				t.Errorf("Expected %v, got %v", want, got)
+			}
+		}
+		if n := src.Subscribers(syscall.SIGUSR1); n != 1 {
+			t.Errorf("Expected 1 subscriber, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// HookError describes the failure of a hook run by Hooks, or of a component
+// started or stopped by Lifecycle.
+type HookError struct {
+	Name     string        // the name of the hook or component
+	Err      error         // the error it returned
+	Duration time.Duration // the time it took
+	TimedOut bool          // whether it returned after its HookTimeout expired
+}
+
+// Error returns the name followed by the error.
+func (e HookError) Error() string {
+	return e.Name + ": " + e.Err.Error()
+}
+
+// Unwrap returns e.Err.
+func (e HookError) Unwrap() error {
+	return e.Err
+}
+
+// HookErrors is the error returned by Hooks.Run and Lifecycle.Run
+// when hooks fail, so that callers can report which ones did.
+type HookErrors []HookError
+
+// Error returns the errors separated by newlines, like errors.Join.
+func (e HookErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+// Unwrap returns the errors, so that errors.Is and errors.As examine each of them.
+func (e HookErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// err returns e as an error, or nil if e is empty.
+func (e HookErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// callHook calls f with ctx, bounded by timeout if positive,
+// and describes its failure, or returns nil if it succeeded.
+func callHook(ctx context.Context, name string, timeout time.Duration, f func(context.Context) error) *HookError {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = clock.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	started := clock.Now()
+	err := f(ctx)
+	if err == nil {
+		return nil
+	}
+	return &HookError{
+		Name:     name,
+		Err:      err,
+		Duration: clock.Since(started),
+		TimedOut: timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded),
+	}
+}
@@ -0,0 +1,56 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestHookErrors(t *testing.T) {
+	clk := signalstest.NewFakeClock(t)
+	var s signals.Hooks
+	errA := errors.New("a failed")
+	s.Add("a", func(context.Context) error { return errA })
+	s.Add("b", func(context.Context) error { return nil })
+	s.Add("c", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, signals.HookTimeout(time.Minute), signals.After("a"))
+
+	result := make(chan error)
+	go func() { result <- s.Run(context.Background()) }()
+	clk.BlockUntil(1)
+	clk.Advance(time.Minute)
+	err := <-result
+	var errs signals.HookErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected HookErrors, got %v", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	if errs[0].Name != "a" || errs[0].Err != errA || errs[0].TimedOut {
+		t.Errorf("Unexpected %+v", errs[0])
+	}
+	if errs[1].Name != "c" || !errs[1].TimedOut || errs[1].Duration != time.Minute {
+		t.Errorf("Unexpected %+v", errs[1])
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected both errors to be found, got %v", err)
+	}
+	var hookErr signals.HookError
+	if !errors.As(err, &hookErr) || hookErr.Name != "a" {
+		t.Errorf("Expected the HookError of a, got %v", hookErr)
+	}
+	if want := "a: a failed\nc: context deadline exceeded"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+
+	if err := new(signals.Hooks).Run(context.Background()); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+}
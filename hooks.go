@@ -0,0 +1,212 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrHookExists is returned by Hooks.Add for a name that is already registered.
+	ErrHookExists = errors.New("signals: hook already exists")
+
+	// ErrHookCycle is returned by Hooks.Add for dependencies that would form a cycle.
+	ErrHookCycle = errors.New("signals: hook dependency cycle")
+)
+
+// Hooks is a set of named shutdown hooks, run in an order derived from the
+// dependencies declared between them rather than from manual priorities.
+//
+// A zero Hooks is empty and ready to use.
+type Hooks struct {
+	mu    sync.Mutex
+	hooks map[string]*hook
+	names []string // in registration order
+	limit int
+}
+
+type hook struct {
+	name    string
+	f       func(ctx context.Context) error
+	after   []string
+	timeout time.Duration
+}
+
+// HookOption configures a hook added with Hooks.Add.
+type HookOption func(*hook)
+
+// After makes the hook run once the hooks of the given names have returned.
+// For example, a cache is shut down after the HTTP server using it with:
+//
+//	shutdown.Add("cache", closeCache, signals.After("http"))
+//
+// Names that are never registered are ignored, so that optional components
+// can be depended on.
+func After(names ...string) HookOption {
+	return func(h *hook) {
+		h.after = append(h.after, names...)
+	}
+}
+
+// HookTimeout bounds the time the hook may take: its context is canceled once
+// d has elapsed, and the HookError of a hook failing past this point reports
+// TimedOut.
+func HookTimeout(d time.Duration) HookOption {
+	return func(h *hook) {
+		h.timeout = d
+	}
+}
+
+// Add registers f as the hook of the given name.
+//
+// It returns an error wrapping ErrHookExists if the name is already registered,
+// or ErrHookCycle if the dependencies of the hook would form a cycle with the
+// hooks already registered; the hook is not registered then.
+func (s *Hooks) Add(name string, f func(ctx context.Context) error, options ...HookOption) error {
+	h := &hook{name: name, f: f}
+	for _, o := range options {
+		o(h)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.hooks[name]; ok {
+		return fmt.Errorf("%w: %s", ErrHookExists, name)
+	}
+	for _, dep := range h.after {
+		if path := s.path(dep, name); path != nil {
+			return fmt.Errorf("%w: %s -> %s", ErrHookCycle, name, strings.Join(path, " -> "))
+		}
+	}
+	if s.hooks == nil {
+		s.hooks = make(map[string]*hook)
+	}
+	s.hooks[name] = h
+	s.names = append(s.names, name)
+	return nil
+}
+
+// path returns the names on a chain of dependencies from the hook from to the
+// hook to, or nil if there is none.
+func (s *Hooks) path(from, to string) []string {
+	if from == to {
+		return []string{to}
+	}
+	h, ok := s.hooks[from]
+	if !ok {
+		return nil
+	}
+	for _, dep := range h.after {
+		if path := s.path(dep, to); path != nil {
+			return append([]string{from}, path...)
+		}
+	}
+	return nil
+}
+
+// SetLimit limits the number of hooks of the same level that Run calls
+// concurrently to at most n. A negative value indicates no limit.
+// By default, and with a limit of 0 or 1, hooks are called one by one.
+func (s *Hooks) SetLimit(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = n
+}
+
+// Order returns the names of the hooks in the order Run calls them, level by
+// level. A hook is of level 0 if it has no registered dependency, and of one
+// level more than its deepest dependency otherwise. Within a level, hooks are
+// in registration order.
+func (s *Hooks) Order() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	order := make([]string, 0, len(s.names))
+	for _, level := range s.levels() {
+		for _, h := range level {
+			order = append(order, h.name)
+		}
+	}
+	return order
+}
+
+func (s *Hooks) levels() [][]*hook {
+	depth := make(map[string]int, len(s.names))
+	var visit func(h *hook) int
+	visit = func(h *hook) int {
+		if d, ok := depth[h.name]; ok {
+			return d
+		}
+		d := 0
+		for _, name := range h.after {
+			if dep, ok := s.hooks[name]; ok {
+				if n := visit(dep) + 1; n > d {
+					d = n
+				}
+			}
+		}
+		depth[h.name] = d
+		return d
+	}
+	var levels [][]*hook
+	for _, name := range s.names {
+		h := s.hooks[name]
+		d := visit(h)
+		for len(levels) <= d {
+			levels = append(levels, nil)
+		}
+		levels[d] = append(levels[d], h)
+	}
+	return levels
+}
+
+// Run calls the hooks level by level with ctx. The hooks of a level are called
+// once all the hooks of the previous level have returned, concurrently up to
+// the limit set with SetLimit. A hook is called even if the hooks it depends on
+// failed.
+//
+// If hooks fail, Run returns a HookErrors with their failures in the order of Order.
+func (s *Hooks) Run(ctx context.Context) error {
+	s.mu.Lock()
+	levels := s.levels()
+	limit := s.limit
+	s.mu.Unlock()
+	var errs HookErrors
+	for _, level := range levels {
+		errs = append(errs, runLevel(ctx, level, limit)...)
+	}
+	return errs.err()
+}
+
+func runLevel(ctx context.Context, level []*hook, limit int) HookErrors {
+	failures := make([]*HookError, len(level))
+	call := func(i int) {
+		failures[i] = callHook(ctx, level[i].name, level[i].timeout, level[i].f)
+	}
+	if limit == 0 || limit == 1 {
+		for i := range level {
+			call(i)
+		}
+	} else {
+		var g Group
+		if limit > 0 {
+			g.SetLimit(limit)
+		}
+		for i := range level {
+			i := i
+			g.Go(func() error {
+				call(i)
+				return nil
+			})
+		}
+		g.Wait()
+	}
+	var errs HookErrors
+	for _, f := range failures {
+		if f != nil {
+			errs = append(errs, *f)
+		}
+	}
+	return errs
+}
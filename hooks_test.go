@@ -0,0 +1,132 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+)
+
+func ExampleHooks() {
+	var shutdown signals.Hooks
+	hook := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			fmt.Println("stopped", name)
+			return nil
+		}
+	}
+	shutdown.Add("db", hook("db"), signals.After("cache", "http"))
+	shutdown.Add("cache", hook("cache"), signals.After("http"))
+	shutdown.Add("http", hook("http"))
+	shutdown.Run(context.Background())
+	// Output:
+	// stopped http
+	// stopped cache
+	// stopped db
+}
+
+func TestHooks(t *testing.T) {
+	nop := func(context.Context) error { return nil }
+
+	t.Run("order", func(t *testing.T) {
+		var s signals.Hooks
+		s.Add("a", nop, signals.After("c"))
+		s.Add("b", nop)
+		s.Add("c", nop, signals.After("missing"))
+		if got, want := s.Order(), []string{"b", "c", "a"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("exists", func(t *testing.T) {
+		var s signals.Hooks
+		s.Add("a", nop)
+		if err := s.Add("a", nop); !errors.Is(err, signals.ErrHookExists) {
+			t.Errorf("Expected ErrHookExists, got %v", err)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		var s signals.Hooks
+		if err := s.Add("a", nop, signals.After("b")); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Add("b", nop, signals.After("c")); err != nil {
+			t.Fatal(err)
+		}
+		err := s.Add("c", nop, signals.After("a"))
+		if !errors.Is(err, signals.ErrHookCycle) {
+			t.Fatalf("Expected ErrHookCycle, got %v", err)
+		}
+		if want := "signals: hook dependency cycle: c -> a -> b -> c"; err.Error() != want {
+			t.Errorf("Expected %q, got %q", want, err.Error())
+		}
+		if err := s.Add("d", nop, signals.After("d")); !errors.Is(err, signals.ErrHookCycle) {
+			t.Errorf("Expected ErrHookCycle, got %v", err)
+		}
+		if got, want := s.Order(), []string{"b", "a"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var s signals.Hooks
+		errA := errors.New("a failed")
+		called := false
+		s.Add("a", func(context.Context) error { return errA })
+		s.Add("b", func(context.Context) error { called = true; return nil }, signals.After("a"))
+		err := s.Run(context.Background())
+		if !errors.Is(err, errA) {
+			t.Errorf("Expected %v, got %v", errA, err)
+		}
+		if want := "a: a failed"; err.Error() != want {
+			t.Errorf("Expected %q, got %q", want, err.Error())
+		}
+		if !called {
+			t.Error("Expected b to be called")
+		}
+	})
+	t.Run("parallel", func(t *testing.T) {
+		var s signals.Hooks
+		s.SetLimit(2)
+		var mu sync.Mutex
+		running, max, finished := 0, 0, 0
+		hook := func(context.Context) error {
+			mu.Lock()
+			running++
+			if running > max {
+				max = running
+			}
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			running--
+			finished++
+			mu.Unlock()
+			return nil
+		}
+		errLast := errors.New("last")
+		for _, name := range []string{"a", "b", "c", "d"} {
+			s.Add(name, hook)
+		}
+		s.Add("last", func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if finished != 4 {
+				t.Errorf("Expected 4 hooks finished, got %d", finished)
+			}
+			return errLast
+		}, signals.After("a", "b", "c", "d"))
+		if err := s.Run(context.Background()); !errors.Is(err, errLast) {
+			t.Errorf("Expected %v, got %v", errLast, err)
+		}
+		if max != 2 {
+			t.Errorf("Expected 2 concurrent hooks, got %d", max)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+// Package clock abstracts the passage of time for the time-based features of
+// package signals, so that package signalstest can substitute a fake clock.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer is the subset of *time.Timer used by package signals.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the subset of *time.Ticker used by package signals.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+var (
+	mu      sync.RWMutex
+	current Clock = Real{}
+)
+
+// Get returns the current clock.
+func Get() Clock {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Set replaces the current clock with c and returns a function that restores the previous one.
+func Set(c Clock) (restore func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	prev := current
+	current = c
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		current = prev
+	}
+}
+
+// Now returns the current time of the current clock.
+func Now() time.Time { return Get().Now() }
+
+// Since returns the time elapsed since t according to the current clock.
+func Since(t time.Time) time.Duration { return Get().Now().Sub(t) }
+
+// NewTimer creates a timer of the current clock.
+func NewTimer(d time.Duration) Timer { return Get().NewTimer(d) }
+
+// NewTicker creates a ticker of the current clock.
+func NewTicker(d time.Duration) Ticker { return Get().NewTicker(d) }
+
+// Real is the Clock backed by package time.
+type Real struct{}
+
+func (Real) Now() time.Time { return time.Now() }
+
+func (Real) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+func (Real) NewTicker(d time.Duration) Ticker { return realTicker{time.NewTicker(d)} }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
+
+// WithTimeout is like context.WithTimeout, except that the timeout is measured
+// by the current clock. With a clock other than Real, the context reports the
+// deadline of parent only, since its own deadline is not on the time of the
+// system, which package net for one compares deadlines with.
+func WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	c := Get()
+	if _, ok := c.(Real); ok {
+		return context.WithTimeout(parent, d)
+	}
+	ctx, cancel := context.WithCancelCause(parent)
+	timer := c.NewTimer(d)
+	go func() {
+		select {
+		case <-timer.C():
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}()
+	return timeoutCtx{ctx}, func() { cancel(context.Canceled) }
+}
+
+// timeoutCtx is the context returned by WithTimeout with a clock other than Real.
+type timeoutCtx struct {
+	context.Context
+}
+
+// Err reports context.DeadlineExceeded once the timer has expired,
+// like the contexts of context.WithTimeout.
+func (t timeoutCtx) Err() error {
+	err := t.Context.Err()
+	if err == context.Canceled && context.Cause(t.Context) == context.DeadlineExceeded {
+		return context.DeadlineExceeded
+	}
+	return err
+}
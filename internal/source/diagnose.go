@@ -0,0 +1,123 @@
+package source
+
+import (
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Diagnose makes Notify call report for each signal that c is subscribed to
+// while subscriptions made from other call sites are live. The sites are
+// formatted as "function (file:line)", the one of c last. A nil signal means
+// all signals. Diagnose returns a function that restores the previous report.
+func Diagnose(report func(sig os.Signal, sites []string)) (restore func()) {
+	r := &injected
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	prev := r.report
+	r.report = report
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.report = prev
+	}
+}
+
+// diagnose records the site of c and finds the subscriptions of other sites
+// overlapping sig. It must be called with r.mu held; the returned function
+// makes the reports and must be called once r.mu is released.
+func (r *registry) diagnose(c chan<- os.Signal, site string, sig []os.Signal) func() {
+	if r.sites == nil {
+		r.sites = make(map[chan<- os.Signal]string)
+	}
+	r.sites[c] = site
+
+	var set map[os.Signal]bool // nil means all signals
+	if len(sig) != 0 {
+		set = make(map[os.Signal]bool)
+		for _, s := range sig {
+			set[s] = true
+		}
+	}
+	others := map[os.Signal]map[string]bool{}
+	add := func(s os.Signal, site string) {
+		if others[s] == nil {
+			others[s] = map[string]bool{}
+		}
+		others[s][site] = true
+	}
+	for d, other := range r.subs {
+		if d == c || r.sites[d] == "" || r.sites[d] == site {
+			continue
+		}
+		switch {
+		case set == nil && other == nil:
+			add(nil, r.sites[d])
+		case set == nil:
+			for s := range other {
+				add(s, r.sites[d])
+			}
+		default:
+			for s := range set {
+				if other == nil || other[s] {
+					add(s, r.sites[d])
+				}
+			}
+		}
+	}
+
+	report := r.report
+	keys := make([]os.Signal, 0, len(others))
+	for s := range others {
+		keys = append(keys, s)
+	}
+	sort.Slice(keys, func(i, j int) bool { return name(keys[i]) < name(keys[j]) })
+	return func() {
+		for _, s := range keys {
+			sites := make([]string, 0, len(others[s])+1)
+			for site := range others[s] {
+				sites = append(sites, site)
+			}
+			sort.Strings(sites)
+			report(s, append(sites, site))
+		}
+	}
+}
+
+func name(sig os.Signal) string {
+	if sig == nil {
+		return ""
+	}
+	return sig.String()
+}
+
+// site returns the first caller outside this module, skipping its tests.
+func site() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		f, more := frames.Next()
+		if !internal(f.Function) || !more {
+			return f.Function + " (" + f.File + ":" + strconv.Itoa(f.Line) + ")"
+		}
+	}
+}
+
+// module is the path of the module of this package.
+const module = "github.com/goaux/signals"
+
+// internal reports whether function belongs to a package of this module,
+// other than a test package.
+func internal(function string) bool {
+	rest, ok := strings.CutPrefix(function, module)
+	if !ok || rest == "" || rest[0] != '.' && rest[0] != '/' {
+		return false
+	}
+	pkg := function[strings.LastIndex(function, "/")+1:]
+	if i := strings.Index(pkg, "."); i >= 0 {
+		pkg = pkg[:i]
+	}
+	return !strings.HasSuffix(pkg, "_test")
+}
@@ -0,0 +1,119 @@
+//go:build js && wasm
+
+package source
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"syscall/js"
+)
+
+// On js/wasm, package os/signal never delivers anything. Instead, events of
+// the host are mapped to signals where possible: under Node.js, the process
+// events of the same name; in a browser, the pagehide event as SIGTERM.
+
+var nodeSignals = map[syscall.Signal]string{
+	syscall.SIGINT:  "SIGINT",
+	syscall.SIGTERM: "SIGTERM",
+}
+
+var (
+	platformMu sync.Mutex
+	listeners  = map[syscall.Signal]*listener{}
+)
+
+// listener relays a host event to the subscribed channels.
+type listener struct {
+	sig  syscall.Signal
+	subs map[chan<- os.Signal]bool
+	fn   js.Func
+}
+
+func process() js.Value {
+	p := js.Global().Get("process")
+	if p.Truthy() && p.Get("on").Type() == js.TypeFunction {
+		return p
+	}
+	return js.Undefined()
+}
+
+func window() js.Value {
+	w := js.Global().Get("window")
+	if w.Truthy() && w.Get("addEventListener").Type() == js.TypeFunction {
+		return w
+	}
+	return js.Undefined()
+}
+
+// Supported reports whether sig can be delivered by the OS source on this platform.
+func Supported(sig os.Signal) bool {
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return false
+	}
+	if _, ok := nodeSignals[s]; ok && process().Truthy() {
+		return true
+	}
+	return s == syscall.SIGTERM && window().Truthy()
+}
+
+func notifyPlatform(c chan<- os.Signal, sig ...os.Signal) {
+	if len(sig) == 0 {
+		for s := range nodeSignals {
+			sig = append(sig, s)
+		}
+	}
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	for _, v := range sig {
+		s, ok := v.(syscall.Signal)
+		if !ok || !Supported(s) {
+			continue
+		}
+		l, ok := listeners[s]
+		if !ok {
+			l = &listener{sig: s, subs: map[chan<- os.Signal]bool{}}
+			l.fn = js.FuncOf(func(js.Value, []js.Value) any {
+				l.deliver()
+				return nil
+			})
+			if p := process(); p.Truthy() {
+				p.Call("on", nodeSignals[s], l.fn)
+			} else {
+				window().Call("addEventListener", "pagehide", l.fn)
+			}
+			listeners[s] = l
+		}
+		l.subs[c] = true
+	}
+}
+
+func stopPlatform(c chan<- os.Signal) {
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	for s, l := range listeners {
+		delete(l.subs, c)
+		if len(l.subs) != 0 {
+			continue
+		}
+		if p := process(); p.Truthy() {
+			p.Call("off", nodeSignals[s], l.fn)
+		} else {
+			window().Call("removeEventListener", "pagehide", l.fn)
+		}
+		l.fn.Release()
+		delete(listeners, s)
+	}
+}
+
+func (l *listener) deliver() {
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	for c := range l.subs {
+		select {
+		case c <- l.sig:
+		default:
+		}
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !(js && wasm) && !wasip1
+
+package source
+
+import "os"
+
+// Supported reports whether sig can be delivered by the OS source on this platform.
+func Supported(sig os.Signal) bool { return true }
+
+func notifyPlatform(c chan<- os.Signal, sig ...os.Signal) {}
+
+func stopPlatform(c chan<- os.Signal) {}
@@ -0,0 +1,13 @@
+//go:build wasip1
+
+package source
+
+import "os"
+
+// Supported reports whether sig can be delivered by the OS source on this platform.
+// WASI provides no way to be notified of the termination of the module.
+func Supported(sig os.Signal) bool { return false }
+
+func notifyPlatform(c chan<- os.Signal, sig ...os.Signal) {}
+
+func stopPlatform(c chan<- os.Signal) {}
@@ -0,0 +1,250 @@
+// Package source abstracts the delivery of OS signals to package signals,
+// so that package signalstest can substitute a fake source.
+package source
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// Source delivers incoming signals to channels, with the semantics of
+// signal.Notify and signal.Stop.
+type Source interface {
+	Notify(c chan<- os.Signal, sig ...os.Signal)
+	Stop(c chan<- os.Signal)
+}
+
+var (
+	mu        sync.RWMutex
+	current   Source = OS{}
+	synthetic bool
+)
+
+// Get returns the current source.
+func Get() Source {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Set replaces the current source with s and returns a function that restores the previous one.
+func Set(s Source) (restore func()) {
+	return set(s, false)
+}
+
+// SetSynthetic is like Set for a source that delivers no OS signals,
+// and reports the signals it delivers with Record instead.
+func SetSynthetic(s Source) (restore func()) {
+	return set(s, true)
+}
+
+func set(s Source, fake bool) (restore func()) {
+	mu.Lock()
+	prev, prevFake := current, synthetic
+	current, synthetic = s, fake
+	mu.Unlock()
+	received.setSynthetic(fake)
+	return func() {
+		mu.Lock()
+		current, synthetic = prev, prevFake
+		mu.Unlock()
+		received.setSynthetic(prevFake)
+	}
+}
+
+// Notify causes the current source to relay incoming signals to c,
+// as well as the signals injected with Inject.
+func Notify(c chan<- os.Signal, sig ...os.Signal) {
+	injected.notify(c, sig...)
+	s := Get()
+	s.Notify(c, sig...)
+	injected.installed(c, s)
+}
+
+// Stop causes the source that c was subscribed with, or the current source if
+// it is not known, to stop relaying incoming signals to c.
+func Stop(c chan<- os.Signal) {
+	injected.source(c).Stop(c)
+	injected.stop(c)
+}
+
+// Inject delivers sig to the channels subscribed to it with Notify, without
+// going through the current source, and returns the number of channels it was
+// delivered to. Like package os/signal, Inject does not block.
+//
+// If all is true, sig is also delivered to the channels subscribed to all signals.
+func Inject(sig os.Signal, all bool) int {
+	return injected.deliver(sig, all)
+}
+
+var injected registry
+
+// registry records subscriptions for Inject.
+type registry struct {
+	mu   sync.Mutex
+	subs map[chan<- os.Signal]map[os.Signal]bool // a nil set means all signals
+
+	report func(sig os.Signal, sites []string) // see Diagnose
+	sites  map[chan<- os.Signal]string
+
+	pending map[chan<- os.Signal]int      // calls to a source in progress
+	via     map[chan<- os.Signal]Source   // the source of the subscriptions in effect
+	waiters map[os.Signal][]chan struct{} // see Ready
+}
+
+func (r *registry) notify(c chan<- os.Signal, sig ...os.Signal) {
+	if report := r.subscribe(c, sig); report != nil {
+		report()
+	}
+}
+
+// subscribe records the subscription of c, and returns the reports of
+// Diagnose to be made once r.mu is released, if enabled.
+func (r *registry) subscribe(c chan<- os.Signal, sig []os.Signal) (report func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.report != nil {
+		report = r.diagnose(c, site(), sig)
+	}
+	if r.subs == nil {
+		r.subs = make(map[chan<- os.Signal]map[os.Signal]bool)
+	}
+	if r.pending == nil {
+		r.pending = make(map[chan<- os.Signal]int)
+	}
+	r.pending[c]++
+	set, ok := r.subs[c]
+	switch {
+	case len(sig) == 0:
+		if ok && set == nil {
+			break
+		}
+		if ok {
+			received.remove(set)
+		}
+		received.add(nil)
+		set = nil
+	case !ok:
+		set = make(map[os.Signal]bool)
+		fallthrough
+	case set != nil:
+		for _, s := range sig {
+			if !set[s] {
+				set[s] = true
+				received.add(s)
+			}
+		}
+	}
+	r.subs[c] = set
+	return report
+}
+
+func (r *registry) stop(c chan<- os.Signal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if set, ok := r.subs[c]; ok {
+		received.remove(set)
+	}
+	delete(r.subs, c)
+	delete(r.sites, c)
+	delete(r.via, c)
+}
+
+// source returns the source the subscription of c is in effect with,
+// or the current source if it is not known.
+func (r *registry) source(c chan<- os.Signal) Source {
+	r.mu.Lock()
+	s, ok := r.via[c]
+	r.mu.Unlock()
+	if !ok {
+		return Get()
+	}
+	return s
+}
+
+// installed records that the subscription of c is in effect with s,
+// and closes the channels returned by Ready that it satisfies.
+func (r *registry) installed(c chan<- os.Signal, s Source) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pending[c]--; r.pending[c] <= 0 {
+		delete(r.pending, c)
+	}
+	if _, ok := r.subs[c]; ok {
+		if r.via == nil {
+			r.via = make(map[chan<- os.Signal]Source)
+		}
+		r.via[c] = s
+	}
+	for sig, waiters := range r.waiters {
+		if !r.subscribed(sig) {
+			continue
+		}
+		for _, w := range waiters {
+			close(w)
+		}
+		delete(r.waiters, sig)
+	}
+}
+
+// subscribed reports whether a channel is subscribed to sig, with the
+// subscription in effect with the current source. It must be called with r.mu held.
+func (r *registry) subscribed(sig os.Signal) bool {
+	current := Get()
+	for c, set := range r.subs {
+		if r.pending[c] == 0 && r.via[c] == current && (set == nil || set[sig]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ready returns a channel that is closed once a channel is subscribed to sig
+// with Notify, and the subscription is in effect with the current source.
+func Ready(sig os.Signal) <-chan struct{} {
+	r := &injected
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	w := make(chan struct{})
+	if r.subscribed(sig) {
+		close(w)
+		return w
+	}
+	if r.waiters == nil {
+		r.waiters = make(map[os.Signal][]chan struct{})
+	}
+	r.waiters[sig] = append(r.waiters[sig], w)
+	return w
+}
+
+func (r *registry) deliver(sig os.Signal, all bool) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for c, set := range r.subs {
+		if set == nil && !all || set != nil && !set[sig] {
+			continue
+		}
+		select {
+		case c <- sig:
+			n++
+		default:
+		}
+	}
+	return n
+}
+
+// OS is the Source backed by package os/signal,
+// complemented on platforms where package os/signal delivers nothing.
+type OS struct{}
+
+func (OS) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	signal.Notify(c, sig...)
+	notifyPlatform(c, sig...)
+}
+
+func (OS) Stop(c chan<- os.Signal) {
+	stopPlatform(c)
+	signal.Stop(c)
+}
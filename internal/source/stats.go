@@ -0,0 +1,170 @@
+package source
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// Stat is the record of the receipts of a signal.
+type Stat struct {
+	Count       uint64
+	First, Last time.Time
+}
+
+// Stats returns a copy of the records of the signals received so far.
+func Stats() map[os.Signal]Stat {
+	received.mu.Lock()
+	defer received.mu.Unlock()
+	stats := make(map[os.Signal]Stat, len(received.stats))
+	for sig, st := range received.stats {
+		stats[sig] = st
+	}
+	return stats
+}
+
+// ResetStats clears the records of the signals received so far.
+func ResetStats() {
+	received.mu.Lock()
+	defer received.mu.Unlock()
+	received.stats = nil
+}
+
+// Record records the receipt of sig. Sources set with SetSynthetic call it
+// for the signals they deliver; the receipts of OS signals are recorded by
+// this package.
+func Record(sig os.Signal) {
+	received.record(sig)
+}
+
+var received counter
+
+// counter records the OS signals received, through a channel of its own that
+// is subscribed to the signals the channels of Notify are subscribed to, so
+// that it counts each signal once and changes neither which signals are
+// caught nor where they are delivered. The subscriptions are counted per
+// signal, so that the channel is resubscribed only when a signal is no longer
+// subscribed to at all.
+type counter struct {
+	mu    sync.Mutex
+	stats map[os.Signal]Stat
+
+	watchMu sync.Mutex
+	ch      chan os.Signal
+	refs    map[os.Signal]int // subscriptions per signal
+	all     int               // subscriptions to all signals
+	fake    bool              // whether the current source is synthetic
+}
+
+func (c *counter) record(sig os.Signal) {
+	now := clock.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stats == nil {
+		c.stats = make(map[os.Signal]Stat)
+	}
+	st := c.stats[sig]
+	if st.Count == 0 {
+		st.First = now
+	}
+	st.Count++
+	st.Last = now
+	c.stats[sig] = st
+}
+
+// add counts a subscription to sig, or to all signals if sig is nil,
+// and subscribes the channel of c to it if it is not watched yet.
+func (c *counter) add(sig os.Signal) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	if sig == nil {
+		c.all++
+		if c.all == 1 && !c.fake {
+			c.open()
+			OS{}.Notify(c.ch)
+		}
+		return
+	}
+	if c.refs == nil {
+		c.refs = make(map[os.Signal]int)
+	}
+	c.refs[sig]++
+	if c.refs[sig] == 1 && c.all == 0 && !c.fake {
+		c.open()
+		OS{}.Notify(c.ch, sig)
+	}
+}
+
+// remove uncounts a subscription to each signal of set, or to all signals if
+// set is nil, and resubscribes the channel of c if a signal is no longer
+// subscribed to.
+func (c *counter) remove(set map[os.Signal]bool) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	if set == nil {
+		c.all--
+		if c.all == 0 {
+			c.resubscribe()
+		}
+		return
+	}
+	changed := false
+	for sig := range set {
+		if c.refs[sig]--; c.refs[sig] <= 0 {
+			delete(c.refs, sig)
+			changed = true
+		}
+	}
+	if changed && c.all == 0 {
+		c.resubscribe()
+	}
+}
+
+// setSynthetic stops watching the OS signals while the current source is
+// synthetic, and watches them again afterwards.
+func (c *counter) setSynthetic(fake bool) {
+	c.watchMu.Lock()
+	defer c.watchMu.Unlock()
+	if c.fake != fake {
+		c.fake = fake
+		c.resubscribe()
+	}
+}
+
+// resubscribe subscribes the channel of c to the signals counted, or stops
+// it if there are none. It must be called with c.watchMu held.
+func (c *counter) resubscribe() {
+	if c.ch != nil {
+		OS{}.Stop(c.ch)
+	}
+	if c.fake || c.all == 0 && len(c.refs) == 0 {
+		if c.ch != nil {
+			close(c.ch)
+			c.ch = nil
+		}
+		return
+	}
+	c.open()
+	if c.all != 0 {
+		OS{}.Notify(c.ch)
+		return
+	}
+	for sig := range c.refs {
+		OS{}.Notify(c.ch, sig)
+	}
+}
+
+// open creates the channel of c if needed. It must be called with c.watchMu held.
+func (c *counter) open() {
+	if c.ch != nil {
+		return
+	}
+	c.ch = make(chan os.Signal, 16)
+	go func(ch <-chan os.Signal) {
+		for sig := range ch {
+			c.record(sig)
+		}
+	}(c.ch)
+}
@@ -0,0 +1,85 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// ForegroundInterval is the interval at which WaitForeground checks whether
+// the process has been moved to the foreground, in addition to SIGCONT.
+const ForegroundInterval = 250 * time.Millisecond
+
+// TrapJobControl traps SIGTTIN and SIGTTOU, and injects BackgroundIO each time
+// one of them is received, until ctx is done.
+//
+// A process of a background job that reads from its terminal, or that writes
+// to it or changes its mode while writes are restricted by TOSTOP, receives
+// SIGTTIN or SIGTTOU, which stop the whole job by default. While they are
+// trapped, the job keeps running, and subscribers of BackgroundIO, such as Wait
+// and Context, are told that the terminal is not available. The call that
+// raised the signal is retried by the Go runtime and raises it again, so
+// interactive prompts should call WaitForeground before using the terminal.
+//
+// On platforms other than Linux, macOS and the BSDs, TrapJobControl only waits
+// for ctx to be done.
+func TrapJobControl(ctx context.Context) {
+	if len(jobControlSignals) == 0 {
+		<-ctx.Done()
+		return
+	}
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, jobControlSignals...)
+	defer source.Stop(ch)
+	for {
+		select {
+		case <-ch:
+			Inject(BackgroundIO)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Foreground reports whether the process belongs to the foreground process
+// group of the terminal fd, such as os.Stdin.Fd(), that is, whether it can use
+// the terminal without receiving SIGTTIN or SIGTTOU.
+//
+// Foreground returns an error wrapping ErrNotTerminal if fd is not the
+// controlling terminal of the process, or ErrUnsupported on platforms other
+// than Linux, macOS and the BSDs.
+func Foreground(fd int) (bool, error) {
+	return foreground(fd)
+}
+
+// WaitForeground blocks until the process belongs to the foreground process
+// group of the terminal fd, or until ctx is done, in which case it returns
+// ctx.Err(). It returns immediately if the process is already in the foreground,
+// or with the error of Foreground if the terminal cannot be queried.
+//
+// The shell continues a job with SIGCONT when it moves the job to the
+// foreground, which wakes WaitForeground up; the terminal is also checked
+// every ForegroundInterval.
+func WaitForeground(ctx context.Context, fd int) error {
+	ch := make(chan os.Signal, 1)
+	if continued != nil {
+		source.Notify(ch, continued)
+		defer source.Stop(ch)
+	}
+	ticker := clock.NewTicker(ForegroundInterval)
+	defer ticker.Stop()
+	for {
+		if fg, err := foreground(fd); err != nil || fg {
+			return err
+		}
+		select {
+		case <-ch:
+		case <-ticker.C():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
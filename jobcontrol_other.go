@@ -0,0 +1,16 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package signals
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// jobControlSignals is empty, since job control is not supported on this platform.
+var jobControlSignals []os.Signal
+
+func foreground(fd int) (bool, error) {
+	return false, fmt.Errorf("%w: terminal on %s/%s", ErrUnsupported, runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,21 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package signals
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var jobControlSignals = []os.Signal{syscall.SIGTTIN, syscall.SIGTTOU}
+
+func foreground(fd int) (bool, error) {
+	var pgrp int32
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), uintptr(syscall.TIOCGPGRP), uintptr(unsafe.Pointer(&pgrp)))
+	if errno != 0 {
+		return false, fmt.Errorf("%w: fd %d: %v", ErrNotTerminal, fd, errno)
+	}
+	return int(pgrp) == syscall.Getpgrp(), nil
+}
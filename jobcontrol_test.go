@@ -0,0 +1,69 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestTrapJobControl(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	sub := signals.Subscribe(1, signals.BackgroundIO)
+	defer sub.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		signals.TrapJobControl(ctx)
+	}()
+
+	for _, sig := range []os.Signal{syscall.SIGTTIN, syscall.SIGTTOU} {
+		if err := src.WaitSubscribed(ctx, sig); err != nil {
+			t.Fatal(err)
+		}
+		src.Send(sig)
+		select {
+		case got := <-sub.C:
+			if got != signals.BackgroundIO {
+				t.Errorf("Expected %v, got %v", signals.BackgroundIO, got)
+			}
+		case <-time.After(signalstest.AssertTimeout):
+			t.Fatalf("Expected %v after %v", signals.BackgroundIO, sig)
+		}
+	}
+
+	cancel()
+	<-done
+	src.AssertNotSubscribed(t, syscall.SIGTTIN)
+}
+
+func TestForeground(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	t.Run("Not a terminal", func(t *testing.T) {
+		if _, err := signals.Foreground(int(r.Fd())); !errors.Is(err, signals.ErrNotTerminal) {
+			t.Errorf("Expected ErrNotTerminal, got %v", err)
+		}
+	})
+
+	t.Run("WaitForeground", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		err := signals.WaitForeground(context.Background(), int(r.Fd()))
+		if !errors.Is(err, signals.ErrNotTerminal) {
+			t.Errorf("Expected ErrNotTerminal, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+// Kind classifies why a context is done, as reported by CauseKind.
+type Kind int
+
+// Kinds of cancellation.
+const (
+	KindNone             Kind = iota // the context is not done
+	KindSignal                       // a signal was received
+	KindCanceled                     // the context or its parent was canceled, including by stop
+	KindDeadlineExceeded             // the deadline of the context or its parent passed
+)
+
+var kindNames = map[Kind]string{
+	KindNone:             "none",
+	KindSignal:           "signal",
+	KindCanceled:         "canceled",
+	KindDeadlineExceeded: "deadline exceeded",
+}
+
+// String returns the name of k.
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return "kind " + strconv.Itoa(int(k))
+}
+
+// CauseKind reports why ctx is done, computed from context.Cause, so that
+// callers can switch on the result instead of inspecting the cause with errors.As.
+//
+// A cause wrapping Canceled is KindSignal, and one wrapping context.DeadlineExceeded
+// is KindDeadlineExceeded. Any other cause, such as context.Canceled or an error
+// given to a context.CancelCauseFunc, is KindCanceled.
+func CauseKind(ctx context.Context) Kind {
+	if ctx.Err() == nil {
+		return KindNone
+	}
+	cause := context.Cause(ctx)
+	switch _, ok := AsCanceled(cause); {
+	case ok:
+		return KindSignal
+	case errors.Is(cause, context.DeadlineExceeded):
+		return KindDeadlineExceeded
+	default:
+		return KindCanceled
+	}
+}
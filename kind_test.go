@@ -0,0 +1,68 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestCauseKind(t *testing.T) {
+	t.Run("none", func(t *testing.T) {
+		if k := signals.CauseKind(context.Background()); k != signals.KindNone {
+			t.Errorf("Expected %v, got %v", signals.KindNone, k)
+		}
+	})
+
+	t.Run("signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		src.Send(syscall.SIGTERM)
+		<-ctx.Done()
+		if k := signals.CauseKind(ctx); k != signals.KindSignal {
+			t.Errorf("Expected %v, got %v", signals.KindSignal, k)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		parent, cancel := context.WithCancelCause(context.Background())
+		ctx, stop := signals.Context(parent, syscall.SIGTERM)
+		defer stop()
+		cancel(errors.New("custom"))
+		if k := signals.CauseKind(ctx); k != signals.KindCanceled {
+			t.Errorf("Expected %v, got %v", signals.KindCanceled, k)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		stop()
+		if k := signals.CauseKind(ctx); k != signals.KindCanceled {
+			t.Errorf("Expected %v, got %v", signals.KindCanceled, k)
+		}
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), -time.Second)
+		defer cancel()
+		if k := signals.CauseKind(ctx); k != signals.KindDeadlineExceeded {
+			t.Errorf("Expected %v, got %v", signals.KindDeadlineExceeded, k)
+		}
+	})
+}
+
+func TestKindString(t *testing.T) {
+	if s := signals.KindSignal.String(); s != "signal" {
+		t.Errorf("Expected signal, got %s", s)
+	}
+	if s := signals.Kind(9).String(); s != "kind 9" {
+		t.Errorf("Expected kind 9, got %s", s)
+	}
+}
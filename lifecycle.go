@@ -0,0 +1,102 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+)
+
+// Lifecycle starts components in order and stops them in reverse order,
+// all under a context canceled by signals.
+//
+// A zero Lifecycle is empty and ready to use.
+type Lifecycle struct {
+	mu         sync.Mutex
+	components []component
+}
+
+type component struct {
+	name  string
+	start func(ctx context.Context) error
+	stop  func(ctx context.Context) error
+}
+
+// OnStart appends a component of the given name, started by start and
+// stopped by stop. Either function may be nil.
+func (l *Lifecycle) OnStart(name string, start, stop func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.components = append(l.components, component{name: name, start: start, stop: stop})
+}
+
+// Run starts the components one by one in the order they were appended, with
+// a context derived from parent canceled when one of the specified signals is
+// received, as by Context. Once all are started, Run waits until this context
+// is done, then stops them in reverse order.
+//
+// If a component fails to start, or the context is done during startup, the
+// components already started are stopped in reverse order right away, and
+// the remaining ones are never started.
+//
+// The stop functions are called with a detached context, as by Detach, since
+// the context of the startup is canceled by then. If a start or stop function
+// fails, Run returns a HookErrors with the start failure first, if any.
+func (l *Lifecycle) Run(parent context.Context, signals ...os.Signal) error {
+	ctx, stop := Context(parent, signals...)
+	defer stop()
+	return l.RunContext(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+}
+
+// RunContext is like Run, except that it uses ctx as is, typically created
+// by NewContext, and that once all components are started, it calls run with
+// ctx and stops the components as soon as run returns.
+//
+// RunContext returns the error of run, joined with a HookErrors if a start
+// or stop function fails. If no start function fails and ctx is not done
+// during startup, run is called.
+func (l *Lifecycle) RunContext(ctx context.Context, run func(ctx context.Context) error) error {
+	l.mu.Lock()
+	components := append([]component(nil), l.components...)
+	l.mu.Unlock()
+
+	var errs HookErrors
+	started := 0
+	for _, c := range components {
+		if ctx.Err() != nil {
+			break
+		}
+		if c.start != nil {
+			if f := callHook(ctx, c.name, 0, c.start); f != nil {
+				errs = append(errs, *f)
+				break
+			}
+		}
+		started++
+	}
+	var err error
+	if started == len(components) && ctx.Err() == nil {
+		err = run(ctx)
+	}
+
+	down := Detach(ctx)
+	for i := started - 1; i >= 0; i-- {
+		c := components[i]
+		if c.stop == nil {
+			continue
+		}
+		if f := callHook(down, c.name, 0, c.stop); f != nil {
+			errs = append(errs, *f)
+		}
+	}
+	switch {
+	case len(errs) == 0:
+		return err
+	case err == nil:
+		return errs
+	}
+	return errors.Join(err, errs)
+}
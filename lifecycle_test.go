@@ -0,0 +1,91 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestLifecycle(t *testing.T) {
+	var events []string
+	component := func(lc *signals.Lifecycle, name string, startErr error) {
+		lc.OnStart(name, func(context.Context) error {
+			events = append(events, "start "+name)
+			return startErr
+		}, func(ctx context.Context) error {
+			if ctx.Err() != nil {
+				t.Errorf("Expected a live context, got %v", ctx.Err())
+			}
+			events = append(events, "stop "+name)
+			return nil
+		})
+	}
+
+	t.Run("signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		events = nil
+		var lc signals.Lifecycle
+		component(&lc, "db", nil)
+		component(&lc, "http", nil)
+		lc.OnStart("ready", func(context.Context) error {
+			src.Send(syscall.SIGTERM)
+			return nil
+		}, nil)
+		if err := lc.Run(context.Background(), syscall.SIGTERM); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		want := []string{"start db", "start http", "stop http", "stop db"}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("Expected %v, got %v", want, events)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		events = nil
+		errStart := errors.New("boom")
+		var lc signals.Lifecycle
+		component(&lc, "db", nil)
+		component(&lc, "cache", nil)
+		component(&lc, "http", errStart)
+		component(&lc, "worker", nil)
+		err := lc.Run(context.Background(), syscall.SIGTERM)
+		if !errors.Is(err, errStart) {
+			t.Errorf("Expected %v, got %v", errStart, err)
+		}
+		if want := "http: boom"; err.Error() != want {
+			t.Errorf("Expected %q, got %q", want, err.Error())
+		}
+		want := []string{"start db", "start cache", "start http", "stop cache", "stop db"}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("Expected %v, got %v", want, events)
+		}
+	})
+	t.Run("RunContext", func(t *testing.T) {
+		events = nil
+		errRun, errStop := errors.New("run"), errors.New("stop")
+		var lc signals.Lifecycle
+		component(&lc, "db", nil)
+		lc.OnStart("cache", nil, func(context.Context) error { return errStop })
+		err := lc.RunContext(context.Background(), func(ctx context.Context) error {
+			events = append(events, "run")
+			return errRun
+		})
+		if !errors.Is(err, errRun) || !errors.Is(err, errStop) {
+			t.Errorf("Expected %v and %v, got %v", errRun, errStop, err)
+		}
+		var errs signals.HookErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Name != "cache" {
+			t.Errorf("Expected the failure of cache, got %v", err)
+		}
+		want := []string{"start db", "run", "stop db"}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("Expected %v, got %v", want, events)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package signals
+
+import (
+	"context"
+	"time"
+)
+
+// Merge returns a context that is canceled as soon as any of ctx and others is done,
+// or when the returned cancel function is called.
+//
+// The cause of the cancellation is preserved: context.Cause of the returned
+// context is the cause of the first input done, so FromContext reports the
+// signal if that input was canceled by one.
+// Values are looked up in ctx first, then in others in order.
+// The deadline is the earliest deadline of the inputs.
+//
+// When ctx expires by its deadline, Err of the returned context reports
+// context.DeadlineExceeded, as for any context derived from ctx. When one of
+// others expires first, Err reports context.Canceled while context.Cause
+// reports context.DeadlineExceeded.
+//
+// Code should call cancel as soon as the operations running in the returned
+// context complete, to release the goroutines watching others.
+func Merge(ctx context.Context, others ...context.Context) (context.Context, context.CancelFunc) {
+	m := &merged{others: others}
+	m.deadline, m.hasDeadline = ctx.Deadline()
+	for _, o := range others {
+		if d, ok := o.Deadline(); ok && (!m.hasDeadline || d.Before(m.deadline)) {
+			m.deadline, m.hasDeadline = d, true
+		}
+	}
+	inner, cancel := context.WithCancelCause(ctx)
+	m.Context = inner
+	for _, o := range others {
+		go func(o context.Context) {
+			select {
+			case <-o.Done():
+				cancel(context.Cause(o))
+			case <-inner.Done():
+			}
+		}(o)
+	}
+	return m, func() { cancel(nil) }
+}
+
+type merged struct {
+	context.Context
+	others      []context.Context
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (m *merged) Deadline() (time.Time, bool) {
+	return m.deadline, m.hasDeadline
+}
+
+func (m *merged) Value(key any) any {
+	if v := m.Context.Value(key); v != nil {
+		return v
+	}
+	for _, o := range m.others {
+		if v := o.Value(key); v != nil {
+			return v
+		}
+	}
+	return nil
+}
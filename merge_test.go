@@ -0,0 +1,104 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestMerge(t *testing.T) {
+	t.Run("Signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		leader, lose := context.WithCancelCause(context.Background())
+		defer lose(nil)
+		sigCtx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+
+		ctx, cancel := signals.Merge(leader, sigCtx)
+		defer cancel()
+
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+	})
+
+	t.Run("Cause of first", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		errLost := errors.New("leadership lost")
+		leader, lose := context.WithCancelCause(context.Background())
+		sigCtx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+
+		ctx, cancel := signals.Merge(sigCtx, leader)
+		defer cancel()
+
+		lose(errLost)
+		<-ctx.Done()
+		if cause := context.Cause(ctx); cause != errLost {
+			t.Errorf("Expected %v, got %v", errLost, cause)
+		}
+		if sig, ok := signals.FromContext(ctx); ok {
+			t.Errorf("Expected no signal, got %v", sig)
+		}
+	})
+
+	t.Run("Values and deadline", func(t *testing.T) {
+		type key struct{ n int }
+		a := context.WithValue(context.Background(), key{1}, "a")
+		b, cancelB := context.WithTimeout(context.WithValue(context.Background(), key{2}, "b"), time.Hour)
+		defer cancelB()
+
+		ctx, cancel := signals.Merge(a, b)
+		defer cancel()
+
+		if v := ctx.Value(key{1}); v != "a" {
+			t.Errorf("Expected a, got %v", v)
+		}
+		if v := ctx.Value(key{2}); v != "b" {
+			t.Errorf("Expected b, got %v", v)
+		}
+		want, _ := b.Deadline()
+		if d, ok := ctx.Deadline(); !ok || !d.Equal(want) {
+			t.Errorf("Expected %v, got %v", want, d)
+		}
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expired, cancelExpired := context.WithDeadline(context.Background(), time.Unix(0, 0))
+		defer cancelExpired()
+
+		ctx, cancel := signals.Merge(expired, context.Background())
+		defer cancel()
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.DeadlineExceeded {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+
+		ctx, cancel = signals.Merge(context.Background(), expired)
+		defer cancel()
+		<-ctx.Done()
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if cause := context.Cause(ctx); cause != context.DeadlineExceeded {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", cause)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := signals.Merge(context.Background(), context.Background())
+		cancel()
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		child, cancelChild := context.WithCancel(ctx)
+		defer cancelChild()
+		if err := child.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package signals
+
+import "os"
+
+// Portable names of common signals.
+//
+// On Unix, Windows and wasip1 they are the syscall signals of the same name.
+// On Plan 9, where signals are notes, they are the notes "interrupt", "hangup"
+// and "alarm"; Plan 9 has no catchable termination note, so Terminate is the
+// "hangup" note, as conventionally sent to processes being shut down.
+// On js/wasm, Hangup and Alarm are placeholders that are never received;
+// Check reports them as unsupported.
+var (
+	Interrupt os.Signal = interrupt
+	Terminate os.Signal = terminate
+	Hangup    os.Signal = hangup
+	Alarm     os.Signal = alarm
+)
@@ -0,0 +1,20 @@
+//go:build js && wasm
+
+package signals
+
+import "syscall"
+
+const (
+	interrupt = syscall.SIGINT
+	terminate = syscall.SIGTERM
+	hangup    = placeholder("hangup")
+	alarm     = placeholder("alarm")
+	pipe      = placeholder("broken pipe")
+)
+
+// placeholder is a signal that does not exist on this platform.
+type placeholder string
+
+func (p placeholder) String() string { return string(p) }
+
+func (placeholder) Signal() {}
@@ -0,0 +1,13 @@
+//go:build !plan9 && !(js && wasm)
+
+package signals
+
+import "syscall"
+
+const (
+	interrupt = syscall.SIGINT
+	terminate = syscall.SIGTERM
+	hangup    = syscall.SIGHUP
+	alarm     = syscall.SIGALRM
+	pipe      = syscall.SIGPIPE
+)
@@ -0,0 +1,38 @@
+//go:build !plan9 && !(js && wasm)
+
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestNames(t *testing.T) {
+	t.Run("Unix", func(t *testing.T) {
+		for _, tc := range []struct {
+			got, want any
+		}{
+			{signals.Interrupt, syscall.SIGINT},
+			{signals.Terminate, syscall.SIGTERM},
+			{signals.Hangup, syscall.SIGHUP},
+			{signals.Alarm, syscall.SIGALRM},
+		} {
+			if tc.got != tc.want {
+				t.Errorf("Expected %v, got %v", tc.want, tc.got)
+			}
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), signals.Interrupt, signals.Hangup)
+		defer stop()
+
+		src.Send(syscall.SIGHUP)
+		signalstest.AssertCanceledBy(t, ctx, signals.Hangup)
+	})
+}
@@ -0,0 +1,11 @@
+package signals
+
+import "syscall"
+
+const (
+	interrupt = syscall.Note("interrupt")
+	terminate = syscall.Note("hangup")
+	hangup    = syscall.Note("hangup")
+	alarm     = syscall.Note("alarm")
+	pipe      = syscall.Note("sys: write on closed pipe")
+)
@@ -0,0 +1,89 @@
+package signals
+
+import (
+	"os"
+	"time"
+)
+
+// Option configures NewContext.
+type Option func(*config)
+
+type config struct {
+	signals       []os.Signal
+	keepListening bool
+	escalation    *Escalation
+	strict        bool
+	grace         time.Duration
+	countdown     func(remaining time.Duration)
+	lockedThread  bool
+	softCancel    bool
+	maxExtension  time.Duration
+	onExtend      func(Extension)
+	filters       []func(os.Signal) bool
+	requeue       time.Duration
+}
+
+// WithSignals specifies the signals to monitor.
+// It can be given multiple times; the signals accumulate.
+func WithSignals(signals ...os.Signal) Option {
+	return func(c *config) {
+		c.signals = append(c.signals, signals...)
+	}
+}
+
+// WithKeepListening keeps the signals registered after the context is canceled
+// by the first signal, until stop is called.
+//
+// Further signals are consumed instead of triggering their default behavior,
+// so that, for example, a second SIGINT during cleanup does not kill the process.
+// They are appended to the History of the context.
+func WithKeepListening() Option {
+	return func(c *config) {
+		c.keepListening = true
+	}
+}
+
+// WithStrict makes NewContext panic if one of the signals given by WithSignals
+// cannot be delivered, as reported by Check, instead of registering a handler
+// that never fires.
+func WithStrict() Option {
+	return func(c *config) {
+		c.strict = true
+	}
+}
+
+// WithGracePeriod specifies the time allowed between the receipt of a signal
+// and the forced termination of the process, as reported by Budget.
+func WithGracePeriod(d time.Duration) Option {
+	return func(c *config) {
+		c.grace = d
+	}
+}
+
+// WithLockedThread makes the goroutine of NewContext that receives the signal
+// and cancels the context run locked to its own OS thread, as by
+// runtime.LockOSThread, for programs whose cgo libraries expect thread affinity.
+//
+// It does not change which thread the system delivers signals to: the Go
+// runtime catches signals on whichever thread the system picks, and relays them
+// to the goroutine. To keep the threads created by C code from receiving
+// signals, block them with BlockThreadSignals before creating the threads.
+func WithLockedThread() Option {
+	return func(c *config) {
+		c.lockedThread = true
+	}
+}
+
+// WithSoftCancel makes a signal close the channel returned by ShuttingDown
+// instead of canceling the context. The context is still canceled when stop
+// is called or when the parent context is done, and FromContext still reports
+// the signal.
+//
+// Use it for handlers, such as long-lived streams, that should finish the
+// element at hand and exit voluntarily, where the cancellation of the context
+// would abort them.
+func WithSoftCancel() Option {
+	return func(c *config) {
+		c.softCancel = true
+	}
+}
@@ -0,0 +1,39 @@
+package signals
+
+import (
+	"context"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// MonitorPauses injects Resumed each time the process resumes from a pause
+// longer than threshold that no signal announces, such as the sleep of a
+// laptop or the pause of a virtual machine, until ctx is done.
+//
+// Pauses are detected with a heartbeat every ResumeInterval: timers follow the
+// monotonic clock, which does not advance while the system is suspended, so
+// the wall-clock time elapsed between two heartbeats exceeds the interval by
+// the length of the pause. A forward step of the wall clock is reported too,
+// since it needs the same re-validation of leases and heartbeats.
+//
+// Subscribe to Resumed, for example with Context or Wait, to handle such
+// pauses like the SIGCONT reported by OnResume.
+func MonitorPauses(ctx context.Context, threshold time.Duration) {
+	last := clock.Now().Round(0)
+	ticker := clock.NewTicker(ResumeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C():
+			// Round(0) strips the monotonic reading, so that Sub uses the wall clock.
+			now := clock.Now().Round(0)
+			if now.Sub(last) > ResumeInterval+threshold {
+				Inject(Resumed)
+			}
+			last = now
+		case <-ctx.Done():
+			return
+		}
+	}
+}
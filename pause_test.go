@@ -0,0 +1,40 @@
+package signals_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestMonitorPauses(t *testing.T) {
+	clk := signalstest.NewFakeClock(t)
+	signalstest.NewFakeSource(t)
+	resumed := signals.Subscribe(1, signals.Resumed)
+	defer resumed.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go signals.MonitorPauses(ctx, time.Second)
+	clk.BlockUntil(1)
+
+	for i := 0; i < 5; i++ {
+		clk.Advance(signals.ResumeInterval)
+	}
+	select {
+	case sig := <-resumed.C:
+		t.Fatalf("Expected no event, got %v", sig)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clk.Advance(time.Hour)
+	select {
+	case sig := <-resumed.C:
+		if sig != signals.Resumed {
+			t.Errorf("Expected %v, got %v", signals.Resumed, sig)
+		}
+	case <-time.After(signalstest.AssertTimeout):
+		t.Error("Expected Resumed")
+	}
+}
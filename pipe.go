@@ -0,0 +1,74 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// PipeMode specifies how HandleSIGPIPE handles SIGPIPE.
+type PipeMode struct {
+	cancel   bool
+	callback func()
+}
+
+var (
+	// PipeIgnore consumes SIGPIPE without further action.
+	PipeIgnore = PipeMode{}
+
+	// PipeCancelContext cancels the context returned by HandleSIGPIPE,
+	// with a Canceled holding SIGPIPE as the cause.
+	PipeCancelContext = PipeMode{cancel: true}
+)
+
+// PipeCallback calls f on every SIGPIPE, from a single goroutine.
+func PipeCallback(f func()) PipeMode {
+	return PipeMode{callback: f}
+}
+
+// HandleSIGPIPE handles SIGPIPE according to mode until the returned stop
+// function is called, and returns a copy of the parent context, canceled by
+// stop or when the parent context is done, also by SIGPIPE with PipeCancelContext.
+//
+// By default, the Go runtime makes the process die of SIGPIPE when it writes to
+// a broken pipe on the file descriptors 1 and 2, that is os.Stdout and os.Stderr,
+// while on other file descriptors the write merely fails with EPIPE.
+// While SIGPIPE is handled, a write to a broken pipe fails with EPIPE whatever
+// the file descriptor, so that, for example, a service logging to stdout
+// survives the restart of the agent reading its logs.
+//
+// Unlike Context, SIGPIPE stays handled after the context is done, until stop
+// is called. On platforms other than Unix, HandleSIGPIPE has no effect beyond
+// the returned context, since there is no SIGPIPE.
+func HandleSIGPIPE(parent context.Context, mode PipeMode) (ctx context.Context, stop func()) {
+	ctx, cancel := context.WithCancelCause(parent)
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, pipe)
+	done := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			source.Stop(ch)
+			close(done)
+			cancel(nil)
+		})
+	}
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				switch {
+				case mode.cancel:
+					cancel(Canceled{Signal: sig})
+				case mode.callback != nil:
+					mode.callback()
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return ctx, stop
+}
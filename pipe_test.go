@@ -0,0 +1,68 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestHandleSIGPIPE(t *testing.T) {
+	t.Run("PipeIgnore", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.HandleSIGPIPE(context.Background(), signals.PipeIgnore)
+		src.AssertSubscribed(t, syscall.SIGPIPE)
+		src.Send(syscall.SIGPIPE)
+		src.Send(syscall.SIGPIPE)
+		if err := ctx.Err(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+		stop()
+		src.AssertNotSubscribed(t, syscall.SIGPIPE)
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("PipeCancelContext", func(t *testing.T) {
+		src := signalstest.NewOSSource(t)
+		ctx, stop := signals.HandleSIGPIPE(context.Background(), signals.PipeCancelContext)
+		defer stop()
+		src.AssertSubscribed(t, syscall.SIGPIPE)
+
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.Close()
+		r.Close()
+		if _, err := w.Write([]byte("x")); !errors.Is(err, syscall.EPIPE) {
+			t.Errorf("Expected EPIPE, got %v", err)
+		}
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGPIPE)
+
+		// SIGPIPE stays handled after the cancellation.
+		src.AssertSubscribed(t, syscall.SIGPIPE)
+	})
+
+	t.Run("PipeCallback", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		called := make(chan struct{})
+		ctx, stop := signals.HandleSIGPIPE(context.Background(), signals.PipeCallback(func() {
+			close(called)
+		}))
+		defer stop()
+		src.AssertSubscribed(t, syscall.SIGPIPE)
+		src.Send(syscall.SIGPIPE)
+		<-called
+		if err := ctx.Err(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+}
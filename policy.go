@@ -0,0 +1,61 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Policy describes the shutdown configured for a context created by NewContext,
+// so that code deep in the call tree can adapt to it, for example by sizing
+// its batches to flush within the grace period, without extra parameters.
+type Policy struct {
+	// Signals are the signals that cancel the context; nil means all signals.
+	Signals []os.Signal
+
+	// Grace is the grace period, as used by Budget.
+	Grace time.Duration
+
+	// Escalation holds the steps of the Escalation given by WithEscalation, if any.
+	Escalation []Step
+
+	// KeepListening reports whether WithKeepListening was given.
+	KeepListening bool
+
+	// SoftCancel reports whether WithSoftCancel was given.
+	SoftCancel bool
+
+	// MaxExtension is the maximum total of the extensions granted by Extend.
+	MaxExtension time.Duration
+}
+
+// PolicyFromContext returns the Policy of the innermost context created by
+// NewContext from which ctx derives, and reports whether there is one.
+// The contexts created by RunControls and Coordinator.Context have the
+// default Policy of their signals, with the default grace period.
+// The slices of the returned Policy are copies.
+func PolicyFromContext(ctx context.Context) (Policy, bool) {
+	st, ok := ctx.Value(stateKey{}).(*state)
+	if !ok {
+		return Policy{}, false
+	}
+	p := st.policy
+	p.Signals = append([]os.Signal(nil), p.Signals...)
+	p.Escalation = append([]Step(nil), p.Escalation...)
+	return p, true
+}
+
+// policy returns the Policy configured by c.
+func (c *config) policy() Policy {
+	p := Policy{
+		Signals:       c.signals,
+		Grace:         gracePeriod(c.grace),
+		KeepListening: c.keepListening,
+		SoftCancel:    c.softCancel,
+		MaxExtension:  c.maxExtension,
+	}
+	if c.escalation != nil {
+		p.Escalation = c.escalation.Steps
+	}
+	return p
+}
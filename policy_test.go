@@ -0,0 +1,85 @@
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestPolicyFromContext(t *testing.T) {
+	t.Run("NewContext", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		ctx, stop := signals.NewContext(context.Background(),
+			signals.WithSignals(syscall.SIGINT, syscall.SIGTERM),
+			signals.WithGracePeriod(20*time.Second),
+			signals.WithEscalation(signals.Escalation{Steps: []signals.Step{
+				{After: 10 * time.Second, Do: signals.CancelHard},
+			}}),
+		)
+		defer stop()
+		ctx = context.WithValue(ctx, struct{}{}, nil)
+
+		p, ok := signals.PolicyFromContext(ctx)
+		if !ok {
+			t.Fatal("Expected a policy")
+		}
+		if len(p.Signals) != 2 || p.Signals[0] != syscall.SIGINT || p.Signals[1] != syscall.SIGTERM {
+			t.Errorf("Expected [SIGINT SIGTERM], got %v", p.Signals)
+		}
+		if p.Grace != 20*time.Second {
+			t.Errorf("Expected 20s, got %v", p.Grace)
+		}
+		if len(p.Escalation) != 1 || p.Escalation[0].After != 10*time.Second {
+			t.Errorf("Expected a step after 10s, got %v", p.Escalation)
+		}
+		if p.KeepListening {
+			t.Error("Expected KeepListening to be false")
+		}
+
+		p.Signals[0] = signals.Hangup
+		if p, _ := signals.PolicyFromContext(ctx); p.Signals[0] != syscall.SIGINT {
+			t.Errorf("Expected SIGINT, got %v", p.Signals[0])
+		}
+	})
+
+	t.Run("Default grace period", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		t.Setenv(signals.GracePeriodEnv, "")
+		ctx, stop := signals.Context(context.Background())
+		defer stop()
+		p, ok := signals.PolicyFromContext(ctx)
+		if !ok || p.Signals != nil || p.Grace != signals.DefaultGracePeriod {
+			t.Errorf("Expected all signals and %v, got %v", signals.DefaultGracePeriod, p)
+		}
+	})
+
+	t.Run("RunControls and Coordinator", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		t.Setenv(signals.GracePeriodEnv, "")
+		check := func(ctx context.Context) {
+			t.Helper()
+			p, ok := signals.PolicyFromContext(ctx)
+			if !ok || len(p.Signals) != 1 || p.Signals[0] != syscall.SIGTERM || p.Grace != signals.DefaultGracePeriod {
+				t.Errorf("Expected [SIGTERM] and %v, got %v", signals.DefaultGracePeriod, p)
+			}
+		}
+		signals.RunControls(context.Background(), func(ctx context.Context, c *signals.Controls) error {
+			check(ctx)
+			return nil
+		}, syscall.SIGTERM)
+		var c signals.Coordinator
+		ctx, stop := c.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		check(ctx)
+	})
+
+	t.Run("No policy", func(t *testing.T) {
+		if _, ok := signals.PolicyFromContext(context.Background()); ok {
+			t.Error("Expected no policy")
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package pprofserver
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// The handlers below serve the same endpoints as package net/http/pprof,
+// which is not imported because its init registers them on
+// http.DefaultServeMux, keeping them open whenever that mux is served.
+
+// newMux returns a mux serving the profiles under /debug/pprof/.
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", index)
+	mux.HandleFunc("/debug/pprof/cmdline", cmdline)
+	mux.HandleFunc("/debug/pprof/profile", profile)
+	mux.HandleFunc("/debug/pprof/symbol", symbol)
+	mux.HandleFunc("/debug/pprof/trace", traceHandler)
+	return mux
+}
+
+// index lists the profiles, or serves the profile named by the path.
+func index(w http.ResponseWriter, r *http.Request) {
+	if name := strings.TrimPrefix(r.URL.Path, "/debug/pprof/"); name != "" {
+		named(w, r, name)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, "<html><head><title>/debug/pprof/</title></head><body>\n")
+	fmt.Fprint(w, "<p>/debug/pprof/</p>\n<table>\n")
+	for _, p := range pprof.Profiles() {
+		name := html.EscapeString(p.Name())
+		fmt.Fprintf(w, "<tr><td>%d</td><td><a href=\"%s?debug=1\">%s</a></td></tr>\n", p.Count(), name, name)
+	}
+	fmt.Fprint(w, "<tr><td></td><td><a href=\"cmdline\">cmdline</a></td></tr>\n")
+	fmt.Fprint(w, "<tr><td></td><td><a href=\"profile\">profile</a></td></tr>\n")
+	fmt.Fprint(w, "<tr><td></td><td><a href=\"trace?seconds=1\">trace</a></td></tr>\n")
+	fmt.Fprint(w, "</table>\n</body></html>\n")
+}
+
+// named serves the profile of runtime/pprof of the given name.
+func named(w http.ResponseWriter, r *http.Request, name string) {
+	p := pprof.Lookup(name)
+	if p == nil {
+		http.Error(w, "unknown profile", http.StatusNotFound)
+		return
+	}
+	debug, _ := strconv.Atoi(r.FormValue("debug"))
+	if name == "heap" && r.FormValue("gc") != "" {
+		runtime.GC()
+	}
+	if debug != 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	}
+	p.WriteTo(w, debug)
+}
+
+// cmdline responds with the command line of the program, its arguments
+// separated by NUL bytes.
+func cmdline(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, strings.Join(os.Args, "\x00"))
+}
+
+// profile responds with the CPU profile recorded for the duration given in
+// seconds by the seconds parameter, 30 by default.
+func profile(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		http.Error(w, "could not enable CPU profiling: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	record(r, 30)
+	pprof.StopCPUProfile()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	w.Write(buf.Bytes())
+}
+
+// traceHandler responds with the execution trace recorded for the duration
+// given in seconds by the seconds parameter, 1 by default.
+func traceHandler(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		http.Error(w, "could not enable tracing: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	record(r, 1)
+	trace.Stop()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="trace"`)
+	w.Write(buf.Bytes())
+}
+
+// record waits for the duration given in seconds by the seconds parameter
+// of r, or def if it is absent, or until the request is canceled.
+func record(r *http.Request, def float64) {
+	sec, err := strconv.ParseFloat(r.FormValue("seconds"), 64)
+	if err != nil || sec <= 0 {
+		sec = def
+	}
+	timer := clock.NewTimer(time.Duration(sec * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+	case <-r.Context().Done():
+	}
+}
+
+// symbol responds with the names of the functions at the program counters
+// listed in the query or in the body of a POST request, separated by '+',
+// in the format of pprof's symbol service.
+func symbol(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	var out bytes.Buffer
+	fmt.Fprint(&out, "num_symbols: 1\n")
+	in := bufio.NewReader(strings.NewReader(r.URL.RawQuery))
+	if r.Method == http.MethodPost {
+		in = bufio.NewReader(r.Body)
+	}
+	for {
+		word, err := in.ReadString('+')
+		if pc, perr := strconv.ParseUint(strings.TrimSuffix(word, "+"), 0, 64); perr == nil && pc != 0 {
+			if f := runtime.FuncForPC(uintptr(pc)); f != nil {
+				fmt.Fprintf(&out, "%#x %s\n", pc, f.Name())
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	w.Write(out.Bytes())
+}
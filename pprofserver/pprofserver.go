@@ -0,0 +1,142 @@
+// Package pprofserver serves the endpoints of net/http/pprof on demand,
+// toggled by a signal.
+//
+// The debug surface stays closed by default but is one signal away:
+//
+//	var debug pprofserver.Server
+//	go debug.Toggle(ctx, syscall.SIGUSR2)
+//
+// After kill -USR2, the profiles are served on an ephemeral localhost port,
+// logged as "pprofserver: serving on http://127.0.0.1:40123/debug/pprof/";
+// the next kill -USR2 stops the server.
+//
+// Unlike importing net/http/pprof, importing this package registers nothing
+// on http.DefaultServeMux.
+package pprofserver
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/internal/clock"
+)
+
+// DefaultAddr is the address used when Server.Addr is empty.
+// Port 0 makes the system choose an ephemeral port.
+const DefaultAddr = "localhost:0"
+
+// Server serves the profiles at the endpoints of net/http/pprof while toggled on.
+type Server struct {
+	// Addr is the TCP address to listen on. If empty, DefaultAddr is used.
+	Addr string
+
+	// Timeout, if positive, stops the server after it has been on for this long.
+	Timeout time.Duration
+
+	// Logf, if not nil, is used instead of log.Printf to report the address
+	// the server is bound to, its stop, and its errors.
+	Logf func(format string, args ...any)
+
+	mu   sync.Mutex
+	addr string
+}
+
+// Toggle starts the server when sig is received, and stops it when sig is
+// received again or when Timeout expires, until ctx is done.
+// The server is stopped before Toggle returns.
+func (s *Server) Toggle(ctx context.Context, sig os.Signal) {
+	w := signals.NewWatcher(1, sig)
+	defer w.Stop()
+	var (
+		srv     *http.Server
+		expired <-chan time.Time
+		timer   clock.Timer
+	)
+	stop := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, expired = nil, nil
+		}
+		s.stop(srv)
+		srv = nil
+	}
+	defer func() {
+		if srv != nil {
+			stop()
+		}
+	}()
+	for {
+		select {
+		case <-w.C:
+			if srv != nil {
+				stop()
+				continue
+			}
+			srv = s.start()
+			if srv != nil && s.Timeout > 0 {
+				timer = clock.NewTimer(s.Timeout)
+				expired = timer.C()
+			}
+		case <-expired:
+			stop()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Address returns the address the server is bound to, or "" if it is off.
+func (s *Server) Address() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+func (s *Server) start() *http.Server {
+	addr := s.Addr
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		s.logf("pprofserver: %v", err)
+		return nil
+	}
+	srv := &http.Server{Handler: newMux()}
+	go func() {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			s.logf("pprofserver: %v", err)
+		}
+	}()
+	s.mu.Lock()
+	s.addr = ln.Addr().String()
+	s.mu.Unlock()
+	s.logf("pprofserver: serving on http://%s/debug/pprof/", ln.Addr())
+	return srv
+}
+
+func (s *Server) stop(srv *http.Server) {
+	if srv == nil {
+		return
+	}
+	srv.Close()
+	s.mu.Lock()
+	s.addr = ""
+	s.mu.Unlock()
+	s.logf("pprofserver: stopped")
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+		return
+	}
+	log.Printf(format, args...)
+}
@@ -0,0 +1,114 @@
+//go:build unix
+
+package pprofserver_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals/pprofserver"
+	"github.com/goaux/signals/signalstest"
+)
+
+// waitAddress waits until the address of s satisfies ok, and returns it.
+func waitAddress(t *testing.T, s *pprofserver.Server, ok func(string) bool) string {
+	t.Helper()
+	var addr string
+	if !signalstest.Eventually(func() bool { addr = s.Address(); return ok(addr) }) {
+		t.Fatalf("Unexpected address %q", addr)
+	}
+	return addr
+}
+
+func on(addr string) bool  { return addr != "" }
+func off(addr string) bool { return addr == "" }
+
+func TestServer(t *testing.T) {
+	t.Run("toggle", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		var logs []string
+		s := &pprofserver.Server{Logf: func(format string, args ...any) { logs = append(logs, format) }}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			s.Toggle(ctx, syscall.SIGUSR2)
+		}()
+		wait, cancelWait := context.WithTimeout(context.Background(), signalstest.AssertTimeout)
+		defer cancelWait()
+		if err := src.WaitSubscribed(wait, syscall.SIGUSR2); err != nil {
+			t.Fatal(err)
+		}
+
+		src.Send(syscall.SIGUSR2)
+		addr := waitAddress(t, s, on)
+		resp, err := http.Get("http://" + addr + "/debug/pprof/")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected %d, got %d", http.StatusOK, resp.StatusCode)
+		}
+
+		resp, err = http.Get("http://" + addr + "/debug/pprof/goroutine?debug=1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if !strings.HasPrefix(string(body), "goroutine profile:") {
+			t.Errorf("Expected a goroutine profile, got %q", body)
+		}
+
+		src.Send(syscall.SIGUSR2)
+		waitAddress(t, s, off)
+		if _, err := http.Get("http://" + addr + "/debug/pprof/"); err == nil {
+			t.Error("Expected the server to be stopped")
+		}
+
+		src.Send(syscall.SIGUSR2)
+		waitAddress(t, s, on)
+		cancel()
+		<-done
+		if addr := s.Address(); addr != "" {
+			t.Errorf("Expected the server to be stopped, got %q", addr)
+		}
+		if len(logs) != 4 {
+			t.Errorf("Expected 4 logs, got %q", logs)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		s := &pprofserver.Server{Timeout: time.Minute, Logf: func(string, ...any) {}}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go s.Toggle(ctx, syscall.SIGUSR2)
+		wait, cancelWait := context.WithTimeout(context.Background(), signalstest.AssertTimeout)
+		defer cancelWait()
+		if err := src.WaitSubscribed(wait, syscall.SIGUSR2); err != nil {
+			t.Fatal(err)
+		}
+		src.Send(syscall.SIGUSR2)
+		waitAddress(t, s, on)
+		clk.BlockUntil(1)
+		clk.Advance(time.Minute)
+		waitAddress(t, s, off)
+	})
+
+	t.Run("DefaultServeMux", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		http.DefaultServeMux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
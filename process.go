@@ -0,0 +1,166 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// Lifetime is a snapshot of the lifetime of a process, as recorded by Process.
+type Lifetime struct {
+	// Started is the time the process started.
+	Started time.Time
+
+	// ShutdownBegan is the time the shutdown began, or the zero time.
+	// If the shutdown was requested by a signal, it is the time the signal was received.
+	ShutdownBegan time.Time
+
+	// ShutdownCompleted is the time the shutdown completed, or the zero time.
+	ShutdownCompleted time.Time
+
+	// Signal is the signal that requested the shutdown, or nil.
+	Signal os.Signal
+}
+
+// Uptime returns the time from the start of the process to the completion of
+// its shutdown, or to now if the shutdown is not completed.
+func (l Lifetime) Uptime() time.Duration {
+	return l.end().Sub(l.Started)
+}
+
+// ShutdownElapsed returns the time from the beginning of the shutdown to its
+// completion, or to now if it is not completed, or zero if it has not begun.
+func (l Lifetime) ShutdownElapsed() time.Duration {
+	if l.ShutdownBegan.IsZero() {
+		return 0
+	}
+	return l.end().Sub(l.ShutdownBegan)
+}
+
+func (l Lifetime) end() time.Time {
+	if l.ShutdownCompleted.IsZero() {
+		return clock.Now()
+	}
+	return l.ShutdownCompleted
+}
+
+// String returns the lifetime formatted as space separated key=value pairs.
+func (l Lifetime) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "uptime=%s", l.Uptime())
+	if !l.ShutdownBegan.IsZero() {
+		fmt.Fprintf(&b, " shutdown=%s", l.ShutdownElapsed())
+	}
+	if l.Signal != nil {
+		fmt.Fprintf(&b, " signal=%q", l.Signal)
+	}
+	return b.String()
+}
+
+// Process records the lifetime of a process: when it started, when its
+// shutdown began and on which signal, and when the shutdown completed, so that
+// the shutdown log line and metrics report consistent numbers.
+//
+// A Process must not be copied after first use.
+type Process struct {
+	mu       sync.Mutex
+	lifetime Lifetime
+	hooks    []func(Lifetime)
+}
+
+var currentProcess = NewProcess()
+
+// CurrentProcess returns the Process of the running process, started when
+// package signals was initialized.
+func CurrentProcess() *Process {
+	return currentProcess
+}
+
+// NewProcess returns a Process started now, for example to record the
+// lifetime of a component that is restarted within the process.
+func NewProcess() *Process {
+	return &Process{lifetime: Lifetime{Started: clock.Now()}}
+}
+
+// BeginShutdown records the beginning of the shutdown, typically once ctx,
+// created by Context, is done. If a signal canceled ctx, the signal and the
+// time it was received are recorded; otherwise the shutdown begins now.
+// Only the first call has effect.
+func (p *Process) BeginShutdown(ctx context.Context) {
+	began := clock.Now()
+	sig, _ := FromContext(ctx)
+	if st, ok := ctx.Value(stateKey{}).(*state); ok && sig != nil {
+		if received := st.receivedAt(); !received.IsZero() {
+			began = received
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.lifetime.ShutdownBegan.IsZero() {
+		p.lifetime.ShutdownBegan = began
+		p.lifetime.Signal = sig
+	}
+}
+
+// CompleteShutdown records the completion of the shutdown, which begins now
+// if BeginShutdown was not called, and calls the functions registered with
+// OnShutdownComplete with the Lifetime, which it returns.
+// Only the first call has effect; later calls return the same Lifetime.
+func (p *Process) CompleteShutdown() Lifetime {
+	now := clock.Now()
+	p.mu.Lock()
+	if !p.lifetime.ShutdownCompleted.IsZero() {
+		defer p.mu.Unlock()
+		return p.lifetime
+	}
+	if p.lifetime.ShutdownBegan.IsZero() {
+		p.lifetime.ShutdownBegan = now
+	}
+	p.lifetime.ShutdownCompleted = now
+	l, hooks := p.lifetime, p.hooks
+	p.hooks = nil
+	p.mu.Unlock()
+	for _, f := range hooks {
+		f(l)
+	}
+	return l
+}
+
+// OnShutdownComplete registers f to be called by CompleteShutdown, for example
+// to log the shutdown line or to record metrics. If the shutdown is already
+// completed, f is called immediately.
+func (p *Process) OnShutdownComplete(f func(Lifetime)) {
+	p.mu.Lock()
+	if p.lifetime.ShutdownCompleted.IsZero() {
+		defer p.mu.Unlock()
+		p.hooks = append(p.hooks, f)
+		return
+	}
+	l := p.lifetime
+	p.mu.Unlock()
+	f(l)
+}
+
+// Lifetime returns a snapshot of the lifetime recorded so far.
+func (p *Process) Lifetime() Lifetime {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.lifetime
+}
+
+// Uptime returns the time from the start of the process to the completion of
+// its shutdown, or to now if the shutdown is not completed.
+func (p *Process) Uptime() time.Duration {
+	return p.Lifetime().Uptime()
+}
+
+// ShutdownElapsed returns the time from the beginning of the shutdown to its
+// completion, or to now if it is not completed, or zero if it has not begun.
+func (p *Process) ShutdownElapsed() time.Duration {
+	return p.Lifetime().ShutdownElapsed()
+}
@@ -0,0 +1,83 @@
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestProcess(t *testing.T) {
+	t.Run("Signal", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		p := signals.NewProcess()
+		var logged []signals.Lifetime
+		p.OnShutdownComplete(func(l signals.Lifetime) { logged = append(logged, l) })
+
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		clk.Advance(time.Hour)
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+		clk.Advance(time.Second)
+		p.BeginShutdown(ctx)
+		if d := p.ShutdownElapsed(); d != time.Second {
+			t.Errorf("Expected 1s, got %v", d)
+		}
+
+		clk.Advance(2 * time.Second)
+		l := p.CompleteShutdown()
+		clk.Advance(time.Minute)
+		if d := p.Uptime(); d != time.Hour+3*time.Second {
+			t.Errorf("Expected 1h0m3s, got %v", d)
+		}
+		if d := l.ShutdownElapsed(); d != 3*time.Second {
+			t.Errorf("Expected 3s, got %v", d)
+		}
+		if l.Signal != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", l.Signal)
+		}
+		want := `uptime=1h0m3s shutdown=3s signal="terminated"`
+		if s := l.String(); s != want {
+			t.Errorf("Expected %q, got %q", want, s)
+		}
+		if len(logged) != 1 || logged[0] != l {
+			t.Errorf("Expected %v to be logged once, got %v", l, logged)
+		}
+		if again := p.CompleteShutdown(); again != l || len(logged) != 1 {
+			t.Errorf("Expected %v once, got %v and %d calls", l, again, len(logged))
+		}
+	})
+
+	t.Run("No signal", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		p := signals.NewProcess()
+		if d := p.ShutdownElapsed(); d != 0 {
+			t.Errorf("Expected 0, got %v", d)
+		}
+		clk.Advance(time.Minute)
+		l := p.CompleteShutdown()
+		if l.Signal != nil || l.ShutdownElapsed() != 0 || l.Uptime() != time.Minute {
+			t.Errorf("Expected an uptime of 1m only, got %v", l)
+		}
+		called := false
+		p.OnShutdownComplete(func(signals.Lifetime) { called = true })
+		if !called {
+			t.Error("Expected f to be called immediately")
+		}
+	})
+
+	t.Run("CurrentProcess", func(t *testing.T) {
+		p := signals.CurrentProcess()
+		if p != signals.CurrentProcess() {
+			t.Error("Expected the same Process")
+		}
+		if p.Lifetime().Started.IsZero() || p.Uptime() <= 0 {
+			t.Errorf("Expected a started process, got %v", p.Lifetime())
+		}
+	})
+}
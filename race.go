@@ -0,0 +1,52 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// Race describes a signal that arrived together with another cancellation of
+// a context created by NewContext, such as the deadline of its parent, which
+// won the race and is the cause of the context.
+type Race struct {
+	// Signal is the signal that lost the race.
+	Signal os.Signal
+
+	// Received is the time the signal was handled, shortly after it arrived.
+	Received time.Time
+
+	// Cause is the cause of the context, as reported by context.Cause.
+	Cause error
+}
+
+// RaceInfo returns the Race lost by a signal of the innermost context created
+// by NewContext from which ctx derives, and reports whether there was one.
+//
+// The cause of a context is the first cancellation to happen; when a signal
+// and the deadline of the parent arrive nearly simultaneously, either may win.
+// Shutdown analytics that attribute terminations by CauseKind can use RaceInfo
+// to tell the terminations that were also requested by a signal.
+//
+// A race is detected when the signal is handled after the context is done:
+// a signal arriving after the context was canceled and its handler stopped is
+// not seen at all.
+func RaceInfo(ctx context.Context) (Race, bool) {
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		st.mu.Lock()
+		defer st.mu.Unlock()
+		if st.race != nil {
+			return *st.race, true
+		}
+	}
+	return Race{}, false
+}
+
+// lost records that sig lost the race against the cancellation of ctx.
+func (s *state) lost(ctx context.Context, sig os.Signal, received time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.race == nil {
+		s.race = &Race{Signal: sig, Received: received, Cause: context.Cause(ctx)}
+	}
+}
@@ -0,0 +1,63 @@
+package signals_test
+
+import (
+	"context"
+	"runtime"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestRaceInfo(t *testing.T) {
+	t.Run("Deadline and signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		parent, cancel := context.WithCancelCause(context.Background())
+		defer cancel(nil)
+		ctx, stop := signals.Context(parent, syscall.SIGTERM)
+		defer stop()
+		src.AssertSubscribed(t, syscall.SIGTERM)
+
+		// With a single P, the goroutine of Context runs once both the signal
+		// and the cancellation of the parent are pending, whichever it sees first.
+		defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+		src.Send(syscall.SIGTERM)
+		cancel(context.DeadlineExceeded)
+
+		var race signals.Race
+		if !signalstest.Eventually(func() (ok bool) { race, ok = signals.RaceInfo(ctx); return ok }) {
+			t.Fatal("Expected a race")
+		}
+		if race.Signal != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", race.Signal)
+		}
+		if race.Cause != context.DeadlineExceeded {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", race.Cause)
+		}
+		if race.Received.IsZero() {
+			t.Error("Expected the time the signal was received")
+		}
+		if k := signals.CauseKind(ctx); k != signals.KindDeadlineExceeded {
+			t.Errorf("Expected %v, got %v", signals.KindDeadlineExceeded, k)
+		}
+	})
+
+	t.Run("Signal only", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), syscall.SIGTERM)
+		defer stop()
+		src.AssertSubscribed(t, syscall.SIGTERM)
+		src.Send(syscall.SIGTERM)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGTERM)
+		if race, ok := signals.RaceInfo(ctx); ok {
+			t.Errorf("Expected no race, got %v", race)
+		}
+	})
+
+	t.Run("Without NewContext", func(t *testing.T) {
+		if _, ok := signals.RaceInfo(context.Background()); ok {
+			t.Error("Expected no race")
+		}
+	})
+}
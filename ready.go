@@ -0,0 +1,19 @@
+package signals
+
+import (
+	"os"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// Ready returns a channel that is closed once a handler for sig is installed
+// by this package, for example by Context, Wait or a Watcher: from then on, sig
+// sent to the process is delivered to that handler instead of triggering its
+// default behavior. If a handler is already installed, the channel is closed.
+//
+// Orchestration code can wait on the channel before sending sig to the
+// process, or before reporting the process as started, instead of sleeping.
+// The channel stays open if no handler is ever installed.
+func Ready(sig os.Signal) <-chan struct{} {
+	return source.Ready(sig)
+}
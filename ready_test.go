@@ -0,0 +1,44 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestReady(t *testing.T) {
+	t.Run("OS signal", func(t *testing.T) {
+		signalstest.NewOSSource(t)
+		ready := signals.Ready(syscall.SIGUSR1)
+		select {
+		case <-ready:
+			t.Fatal("Expected no handler yet")
+		default:
+		}
+
+		received := make(chan os.Signal, 1)
+		go func() { received <- signals.Wait(context.Background(), syscall.SIGUSR1) }()
+		<-ready
+		syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+		if sig := <-received; sig != syscall.SIGUSR1 {
+			t.Errorf("Expected %v, got %v", syscall.SIGUSR1, sig)
+		}
+	})
+
+	t.Run("Already installed", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		sub := signals.Subscribe(1)
+		defer sub.Close()
+		select {
+		case <-signals.Ready(syscall.SIGHUP):
+		default:
+			t.Error("Expected the handler of all signals to be ready")
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// ResumeInterval is the interval of the heartbeat by which OnResume
+// estimates how long the process was stopped, and so its accuracy.
+const ResumeInterval = 100 * time.Millisecond
+
+// OnResume calls f each time SIGCONT is received, with an estimate of how long
+// the process was stopped, until ctx is done.
+//
+// SIGSTOP itself cannot be caught, but a process stopped by it, by the cgroup
+// freezer, by a debugger or by job control is continued with SIGCONT. After
+// such a pause, time-sensitive code, such as holders of leases or senders of
+// heartbeats, should re-validate their state before going on.
+//
+// The estimate is the time elapsed since the last heartbeat of OnResume, so it
+// is accurate to within ResumeInterval. On platforms without SIGCONT, such as
+// Windows, f is never called.
+func OnResume(ctx context.Context, f func(stopped time.Duration)) {
+	if continued == nil {
+		<-ctx.Done()
+		return
+	}
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, continued)
+	defer source.Stop(ch)
+	last := clock.Now()
+	ticker := clock.NewTicker(ResumeInterval)
+	defer ticker.Stop()
+
+	// After a pause, heartbeats may be handled before SIGCONT,
+	// so the last gap they observed is kept for a while.
+	var (
+		gap      time.Duration
+		observed time.Time
+	)
+	for {
+		select {
+		case <-ticker.C():
+			if d := clock.Since(last); d > 2*ResumeInterval {
+				gap, observed = d, clock.Now()
+			}
+			last = clock.Now()
+		case <-ch:
+			stopped := clock.Since(last)
+			if gap > stopped && clock.Since(observed) < 10*ResumeInterval {
+				stopped = gap
+			}
+			gap = 0
+			f(stopped)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
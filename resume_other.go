@@ -0,0 +1,8 @@
+//go:build !unix
+
+package signals
+
+import "os"
+
+// continued is nil, since there is no SIGCONT on this platform.
+var continued os.Signal
@@ -0,0 +1,33 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestOnResume(t *testing.T) {
+	clk := signalstest.NewFakeClock(t)
+	src := signalstest.NewFakeSource(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resumed := make(chan time.Duration)
+	go signals.OnResume(ctx, func(stopped time.Duration) { resumed <- stopped })
+	clk.BlockUntil(1)
+	if err := src.WaitSubscribed(ctx, syscall.SIGCONT); err != nil {
+		t.Fatal(err)
+	}
+
+	// While the process is stopped, neither the heartbeat nor SIGCONT are handled.
+	clk.Advance(5 * time.Second)
+	src.Send(syscall.SIGCONT)
+	if got := <-resumed; got < 5*time.Second-signals.ResumeInterval || got > 5*time.Second {
+		t.Errorf("Expected about 5s, got %v", got)
+	}
+}
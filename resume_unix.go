@@ -0,0 +1,10 @@
+//go:build unix
+
+package signals
+
+import (
+	"os"
+	"syscall"
+)
+
+var continued os.Signal = syscall.SIGCONT
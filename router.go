@@ -0,0 +1,161 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// ErrNoRoute is returned by Router.Dispatch for a key without a handler.
+var ErrNoRoute = errors.New("signals: no route")
+
+// RouteHandler handles a signal routed to a key of a Router.
+type RouteHandler func(ctx context.Context, sig os.Signal) error
+
+// KeySource determines the keys targeted by a received signal.
+type KeySource interface {
+	Keys(sig os.Signal) ([]string, error)
+}
+
+// KeySourceFunc is an adapter to allow the use of ordinary functions as a KeySource.
+type KeySourceFunc func(sig os.Signal) ([]string, error)
+
+// Keys calls f(sig).
+func (f KeySourceFunc) Keys(sig os.Signal) ([]string, error) {
+	return f(sig)
+}
+
+// SignalKeys returns a KeySource mapping each signal to a fixed key,
+// for example a realtime signal number to a tenant.
+func SignalKeys(m map[os.Signal]string) KeySource {
+	return KeySourceFunc(func(sig os.Signal) ([]string, error) {
+		if key, ok := m[sig]; ok {
+			return []string{key}, nil
+		}
+		return nil, nil
+	})
+}
+
+// TouchFiles returns a KeySource that follows the convention of touching a file
+// named after the target in dir before sending the signal.
+// The names of the regular files in dir are the keys; the files are removed once read.
+func TouchFiles(dir string) KeySource {
+	return KeySourceFunc(func(os.Signal) ([]string, error) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		var keys []string
+		var errs []error
+		for _, e := range entries {
+			if !e.Type().IsRegular() {
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, e.Name())); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			keys = append(keys, e.Name())
+		}
+		return keys, errors.Join(errs...)
+	})
+}
+
+// Router routes signals to handlers registered by key,
+// so that a multi-tenant daemon can target, for example, a reload at one tenant.
+//
+// The zero value is an empty Router ready to use.
+type Router struct {
+	// OnError, if not nil, is called by Listen with the errors of key sources and handlers.
+	OnError func(err error)
+
+	mu       sync.RWMutex
+	handlers map[string]RouteHandler
+}
+
+// Handle registers h for key, replacing any previous handler for key.
+func (r *Router) Handle(key string, h RouteHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.handlers == nil {
+		r.handlers = make(map[string]RouteHandler)
+	}
+	r.handlers[key] = h
+}
+
+// Remove unregisters the handler for key.
+func (r *Router) Remove(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.handlers, key)
+}
+
+// Lookup returns the handler registered for key.
+func (r *Router) Lookup(key string) (RouteHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	h, ok := r.handlers[key]
+	return h, ok
+}
+
+// Keys returns the registered keys in sorted order.
+func (r *Router) Keys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]string, 0, len(r.handlers))
+	for key := range r.handlers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Dispatch calls the handlers registered for keys with sig, in order.
+// Errors of the handlers, and ErrNoRoute for keys without a handler, are joined and returned.
+func (r *Router) Dispatch(ctx context.Context, sig os.Signal, keys ...string) error {
+	var errs []error
+	for _, key := range keys {
+		h, ok := r.Lookup(key)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %q", ErrNoRoute, key))
+			continue
+		}
+		if err := h(ctx, sig); err != nil {
+			errs = append(errs, fmt.Errorf("signals: route %q: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Listen dispatches the specified signals until ctx is done.
+// For each received signal, the keys are obtained from src.
+//
+// If no signals are provided, all incoming signals will be relayed.
+// Otherwise, only the provided signals will be monitored.
+func (r *Router) Listen(ctx context.Context, src KeySource, signals ...os.Signal) {
+	ch := make(chan os.Signal, 16)
+	source.Notify(ch, signals...)
+	defer source.Stop(ch)
+	for {
+		select {
+		case sig := <-ch:
+			keys, err := src.Keys(sig)
+			r.report(err)
+			r.report(r.Dispatch(ctx, sig, keys...))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *Router) report(err error) {
+	if err != nil && r.OnError != nil {
+		r.OnError(err)
+	}
+}
@@ -0,0 +1,96 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestRouter(t *testing.T) {
+	t.Run("Dispatch", func(t *testing.T) {
+		var r signals.Router
+		var got []string
+		record := func(name string) signals.RouteHandler {
+			return func(ctx context.Context, sig os.Signal) error {
+				got = append(got, name)
+				return nil
+			}
+		}
+		r.Handle("a", record("a"))
+		r.Handle("b", record("b"))
+
+		if keys := r.Keys(); !reflect.DeepEqual(keys, []string{"a", "b"}) {
+			t.Errorf("Expected [a b], got %v", keys)
+		}
+		err := r.Dispatch(context.Background(), syscall.SIGUSR1, "b", "c")
+		if !errors.Is(err, signals.ErrNoRoute) {
+			t.Errorf("Expected ErrNoRoute, got %v", err)
+		}
+		if !reflect.DeepEqual(got, []string{"b"}) {
+			t.Errorf("Expected [b], got %v", got)
+		}
+
+		r.Remove("b")
+		if _, ok := r.Lookup("b"); ok {
+			t.Error("Expected b to be removed")
+		}
+	})
+
+	t.Run("Listen", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		got := make(chan string, 1)
+		var r signals.Router
+		r.Handle("tenant-1", func(ctx context.Context, sig os.Signal) error {
+			got <- "tenant-1"
+			return nil
+		})
+		keys := signals.SignalKeys(map[os.Signal]string{syscall.SIGUSR1: "tenant-1"})
+		go r.Listen(ctx, keys, syscall.SIGUSR1, syscall.SIGUSR2)
+
+		src.WaitSubscribed(ctx, syscall.SIGUSR1)
+		src.Send(syscall.SIGUSR1)
+		select {
+		case key := <-got:
+			if key != "tenant-1" {
+				t.Errorf("Expected tenant-1, got %v", key)
+			}
+		case <-ctx.Done():
+			t.Fatal("Expected the handler to be called")
+		}
+	})
+
+	t.Run("TouchFiles", func(t *testing.T) {
+		dir := t.TempDir()
+		for _, name := range []string{"x", "y"} {
+			if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		os.Mkdir(filepath.Join(dir, "sub"), 0o700)
+
+		keys, err := signals.TouchFiles(dir).Keys(syscall.SIGUSR1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(keys, []string{"x", "y"}) {
+			t.Errorf("Expected [x y], got %v", keys)
+		}
+		keys, _ = signals.TouchFiles(dir).Keys(syscall.SIGUSR1)
+		if len(keys) != 0 {
+			t.Errorf("Expected the files to be removed, got %v", keys)
+		}
+	})
+}
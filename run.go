@@ -0,0 +1,71 @@
+package signals
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// Report summarizes a call of Run, suitable for a single shutdown log line.
+type Report struct {
+	// Signal is the signal received, or nil if none was received.
+	Signal os.Signal
+
+	// Received is the time the signal was received, or the zero time.
+	Received time.Time
+
+	// Started and Returned are the times run was called and returned.
+	Started, Returned time.Time
+
+	// Err is the error returned by run.
+	Err error
+}
+
+// Duration returns the time run took.
+func (r Report) Duration() time.Duration {
+	return r.Returned.Sub(r.Started)
+}
+
+// Shutdown returns the time from the receipt of the signal to the return of run,
+// or zero if no signal was received.
+func (r Report) Shutdown() time.Duration {
+	if r.Signal == nil {
+		return 0
+	}
+	return r.Returned.Sub(r.Received)
+}
+
+// String returns the report formatted as space separated key=value pairs.
+func (r Report) String() string {
+	var b strings.Builder
+	if r.Signal != nil {
+		fmt.Fprintf(&b, "signal=%q received=%s shutdown=%s ", r.Signal, r.Received.Format(time.RFC3339Nano), r.Shutdown())
+	}
+	fmt.Fprintf(&b, "duration=%s", r.Duration())
+	if r.Err != nil {
+		fmt.Fprintf(&b, " error=%q", r.Err)
+	}
+	return b.String()
+}
+
+// Run calls run with a context created by Context and returns a Report
+// along with the error returned by run.
+//
+// The context passed to run is stopped when run returns.
+func Run(parent context.Context, run func(ctx context.Context) error, signals ...os.Signal) (Report, error) {
+	ctx, stop := Context(parent, signals...)
+	defer stop()
+
+	r := Report{Started: clock.Now()}
+	r.Err = run(ctx)
+	r.Returned = clock.Now()
+	if st, ok := ctx.Value(stateKey{}).(*state); ok {
+		r.Signal, _ = st.get()
+		r.Received = st.receivedAt()
+	}
+	return r, r.Err
+}
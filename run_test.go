@@ -0,0 +1,77 @@
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func ExampleRun() {
+	report, err := signals.Run(context.Background(), func(ctx context.Context) error {
+		return nil
+	}, syscall.SIGINT, syscall.SIGTERM)
+
+	fmt.Println(report.Signal, err)
+	// Output:
+	// <nil> <nil>
+}
+
+func TestRun(t *testing.T) {
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clock := signalstest.NewFakeClock(t)
+		start := clock.Now()
+
+		report, err := signals.Run(context.Background(), func(ctx context.Context) error {
+			clock.Advance(time.Second)
+			src.Send(syscall.SIGTERM)
+			<-ctx.Done()
+			clock.Advance(2 * time.Second)
+			return ctx.Err()
+		}, syscall.SIGTERM)
+
+		if err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if report.Signal != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", report.Signal)
+		}
+		if want := start.Add(time.Second); !report.Received.Equal(want) {
+			t.Errorf("Expected %v, got %v", want, report.Received)
+		}
+		if d := report.Duration(); d != 3*time.Second {
+			t.Errorf("Expected 3s, got %v", d)
+		}
+		if d := report.Shutdown(); d != 2*time.Second {
+			t.Errorf("Expected 2s, got %v", d)
+		}
+		if s := report.String(); !strings.Contains(s, `signal="terminated"`) || !strings.Contains(s, "shutdown=2s") {
+			t.Errorf("Unexpected report %q", s)
+		}
+	})
+
+	t.Run("No signal", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		errRun := errors.New("run")
+		report, err := signals.Run(context.Background(), func(ctx context.Context) error {
+			return errRun
+		}, syscall.SIGTERM)
+
+		if err != errRun || report.Err != errRun {
+			t.Errorf("Expected %v, got %v and %v", errRun, err, report.Err)
+		}
+		if report.Signal != nil || !report.Received.IsZero() || report.Shutdown() != 0 {
+			t.Errorf("Expected no signal, got %+v", report)
+		}
+		if s := report.String(); strings.Contains(s, "signal=") {
+			t.Errorf("Unexpected report %q", s)
+		}
+	})
+}
@@ -0,0 +1,21 @@
+package signals
+
+import "os"
+
+// BlockThreadSignals blocks the specified signals in the current OS thread,
+// and returns a function that restores the previous signal mask of the thread.
+// It is available on Linux only.
+//
+// The calling goroutine must be locked to its thread with runtime.LockOSThread,
+// and must call restore before unlocking it, since the Go runtime does not
+// restore the signal mask of a thread it reuses.
+//
+// Threads created by C code, such as the worker threads of a cgo library,
+// inherit the signal mask of the thread that creates them. Calling the
+// function of the library that creates the threads between BlockThreadSignals
+// and restore keeps them from receiving the signals, which are then handled
+// by the threads of the Go runtime and delivered by this package as usual.
+// The threads of the Go runtime itself are not affected.
+func BlockThreadSignals(sigs ...os.Signal) (restore func(), err error) {
+	return blockThreadSignals(sigs)
+}
@@ -0,0 +1,35 @@
+package signals
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// sigset is the kernel signal set of rt_sigprocmask.
+type sigset [sigsetWords]uint64
+
+func blockThreadSignals(sigs []os.Signal) (restore func(), err error) {
+	var set, old sigset
+	for _, sig := range sigs {
+		s, ok := sig.(syscall.Signal)
+		if !ok || s < 1 || int(s) > 64*sigsetWords {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupported, sig)
+		}
+		set[(s-1)/64] |= 1 << ((s - 1) % 64)
+	}
+	if err := sigprocmask(sigBlock, &set, &old); err != nil {
+		return nil, err
+	}
+	return func() { sigprocmask(sigSetmask, &old, nil) }, nil
+}
+
+func sigprocmask(how int, set, old *sigset) error {
+	_, _, errno := syscall.RawSyscall6(syscall.SYS_RT_SIGPROCMASK, uintptr(how),
+		uintptr(unsafe.Pointer(set)), uintptr(unsafe.Pointer(old)), sigsetBytes, 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
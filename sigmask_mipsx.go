@@ -0,0 +1,13 @@
+//go:build linux && (mips || mipsle || mips64 || mips64le)
+
+package signals
+
+// The kernel has 128 signals on MIPS, and numbers the operations of
+// rt_sigprocmask differently.
+const (
+	sigsetWords = 2
+	sigsetBytes = 16
+
+	sigBlock   = 1
+	sigSetmask = 3
+)
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package signals
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+func blockThreadSignals(sigs []os.Signal) (restore func(), err error) {
+	return nil, fmt.Errorf("%w: thread signal mask on %s/%s", ErrUnsupported, runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,11 @@
+//go:build linux && !(mips || mipsle || mips64 || mips64le)
+
+package signals
+
+const (
+	sigsetWords = 1
+	sigsetBytes = 8
+
+	sigBlock   = 0
+	sigSetmask = 2
+)
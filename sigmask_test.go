@@ -0,0 +1,68 @@
+//go:build linux
+
+package signals_test
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/goaux/signals"
+)
+
+// blockedSignals returns the signal mask of the current thread.
+func blockedSignals(t *testing.T) uint64 {
+	t.Helper()
+	f, err := os.Open("/proc/thread-self/status")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if v, ok := strings.CutPrefix(sc.Text(), "SigBlk:"); ok {
+			mask, err := strconv.ParseUint(strings.TrimSpace(v), 16, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return mask
+		}
+	}
+	t.Skip("SigBlk not found")
+	return 0
+}
+
+func TestBlockThreadSignals(t *testing.T) {
+	t.Run("Block and restore", func(t *testing.T) {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		bit := uint64(1) << (syscall.SIGUSR1 - 1)
+		if blockedSignals(t)&bit != 0 {
+			t.Skip("SIGUSR1 is already blocked")
+		}
+
+		restore, err := signals.BlockThreadSignals(syscall.SIGUSR1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if blockedSignals(t)&bit == 0 {
+			t.Error("Expected SIGUSR1 to be blocked")
+		}
+		restore()
+		if blockedSignals(t)&bit != 0 {
+			t.Error("Expected SIGUSR1 to be unblocked")
+		}
+	})
+
+	t.Run("Unsupported signal", func(t *testing.T) {
+		_, err := signals.BlockThreadSignals(os.Interrupt, syscall.Signal(0))
+		if !errors.Is(err, signals.ErrUnsupported) {
+			t.Errorf("Expected ErrUnsupported, got %v", err)
+		}
+	})
+}
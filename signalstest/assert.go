@@ -0,0 +1,45 @@
+package signalstest
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+)
+
+// AssertTimeout is the maximum duration assertions wait for asynchronous effects.
+var AssertTimeout = 5 * time.Second
+
+// AssertCanceledBy reports an error to tb unless ctx is canceled by sig,
+// as reported by signals.FromContext, within AssertTimeout.
+func AssertCanceledBy(tb testing.TB, ctx context.Context, sig os.Signal) {
+	tb.Helper()
+	select {
+	case <-ctx.Done():
+	case <-time.After(AssertTimeout):
+		tb.Errorf("Expected the context to be canceled by %v, but it is not canceled", sig)
+		return
+	}
+	got, ok := signals.FromContext(ctx)
+	if !ok {
+		tb.Errorf("Expected the context to be canceled by %v, got %v", sig, context.Cause(ctx))
+	} else if got != sig {
+		tb.Errorf("Expected the context to be canceled by %v, got %v", sig, got)
+	}
+}
+
+// Eventually calls cond repeatedly until it returns true or AssertTimeout elapses,
+// and reports whether cond returned true. It is meant for effects that have nothing
+// to wait on, such as a counter updated by another goroutine.
+func Eventually(cond func() bool) bool {
+	deadline := time.Now().Add(AssertTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
@@ -0,0 +1,148 @@
+package signalstest
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+)
+
+// FakeClock is a clock whose time advances only when Advance is called.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	changed chan struct{}
+}
+
+// NewFakeClock returns a FakeClock and installs it as the clock used by package signals
+// until the end of the test.
+func NewFakeClock(tb testing.TB) *FakeClock {
+	c := &FakeClock{
+		now:     time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		changed: make(chan struct{}),
+	}
+	tb.Cleanup(clock.Set(c))
+	return c
+}
+
+// Now returns the current time of the fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance advances the fake clock by d, firing the timers and tickers that expire meanwhile.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	target := c.now.Add(d)
+	for {
+		sort.SliceStable(c.waiters, func(i, j int) bool {
+			return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+		})
+		if len(c.waiters) == 0 || c.waiters[0].deadline.After(target) {
+			break
+		}
+		w := c.waiters[0]
+		c.now = w.deadline
+		select {
+		case w.ch <- c.now:
+		default:
+		}
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+		} else {
+			c.remove(w)
+		}
+	}
+	c.now = target
+}
+
+// BlockUntil blocks until at least n timers or tickers are active on the fake clock.
+func (c *FakeClock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		active, changed := len(c.waiters), c.changed
+		c.mu.Unlock()
+		if active >= n {
+			return
+		}
+		<-changed
+	}
+}
+
+// NewTimer implements the clock interface of package signals.
+func (c *FakeClock) NewTimer(d time.Duration) clock.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1)}
+	c.add(w, d)
+	return w
+}
+
+// NewTicker implements the clock interface of package signals.
+func (c *FakeClock) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("signalstest: non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := &fakeWaiter{clock: c, ch: make(chan time.Time, 1), period: d}
+	c.add(w, d)
+	return fakeTicker{w}
+}
+
+func (c *FakeClock) add(w *fakeWaiter, d time.Duration) {
+	w.deadline = c.now.Add(d)
+	c.waiters = append(c.waiters, w)
+	c.notify()
+}
+
+func (c *FakeClock) remove(w *fakeWaiter) bool {
+	for i, v := range c.waiters {
+		if v == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.notify()
+			return true
+		}
+	}
+	return false
+}
+
+func (c *FakeClock) notify() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+type fakeWaiter struct {
+	clock    *FakeClock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration
+}
+
+func (w *fakeWaiter) C() <-chan time.Time { return w.ch }
+
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	return w.clock.remove(w)
+}
+
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := w.clock.remove(w)
+	w.clock.add(w, d)
+	return active
+}
+
+type fakeTicker struct{ w *fakeWaiter }
+
+func (t fakeTicker) C() <-chan time.Time { return t.w.ch }
+
+func (t fakeTicker) Stop() { t.w.Stop() }
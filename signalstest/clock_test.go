@@ -0,0 +1,77 @@
+package signalstest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestFakeClock(t *testing.T) {
+	t.Run("Timer", func(t *testing.T) {
+		c := signalstest.NewFakeClock(t)
+		start := c.Now()
+		timer := clock.NewTimer(time.Second)
+
+		c.Advance(999 * time.Millisecond)
+		select {
+		case <-timer.C():
+			t.Fatal("Expected the timer not to fire")
+		default:
+		}
+
+		c.Advance(time.Millisecond)
+		select {
+		case now := <-timer.C():
+			if want := start.Add(time.Second); !now.Equal(want) {
+				t.Errorf("Expected %v, got %v", want, now)
+			}
+		default:
+			t.Fatal("Expected the timer to fire")
+		}
+		if timer.Stop() {
+			t.Error("Expected Stop to report an expired timer")
+		}
+	})
+
+	t.Run("Ticker", func(t *testing.T) {
+		c := signalstest.NewFakeClock(t)
+		ticker := clock.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for i := 0; i < 3; i++ {
+			c.Advance(time.Second)
+			select {
+			case <-ticker.C():
+			default:
+				t.Fatalf("Expected tick %d", i)
+			}
+		}
+	})
+
+	t.Run("BlockUntil", func(t *testing.T) {
+		c := signalstest.NewFakeClock(t)
+		fired := make(chan struct{})
+		go func() {
+			<-clock.NewTimer(time.Minute).C()
+			close(fired)
+		}()
+
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+		<-fired
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		t.Run("Install", func(t *testing.T) {
+			signalstest.NewFakeClock(t)
+			if _, ok := clock.Get().(*signalstest.FakeClock); !ok {
+				t.Error("Expected the fake clock to be installed")
+			}
+		})
+		if _, ok := clock.Get().(clock.Real); !ok {
+			t.Error("Expected the real clock to be restored")
+		}
+	})
+}
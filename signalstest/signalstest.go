@@ -0,0 +1,6 @@
+// Package signalstest provides utilities for testing code that uses package signals.
+//
+// The fakes in this package replace process-wide state of package signals for
+// the duration of a test, so tests using them must not run in parallel with
+// each other.
+package signalstest
@@ -0,0 +1,210 @@
+package signalstest
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"testing"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// FakeSource is a source of signals that never receives real OS signals.
+// Signals are injected with Send instead.
+type FakeSource struct {
+	registry
+}
+
+// OSSource is a source of signals that relays real OS signals, like package os/signal,
+// while recording subscriptions so that tests can wait for them before sending a signal
+// to the process.
+type OSSource struct {
+	registry
+}
+
+// registry records the subscriptions made through a source.
+type registry struct {
+	mu      sync.Mutex
+	subs    map[chan<- os.Signal]subscription
+	changed chan struct{}
+	calls   int
+}
+
+func (s *registry) init() {
+	s.subs = make(map[chan<- os.Signal]subscription)
+	s.changed = make(chan struct{})
+}
+
+// subscription is the set of signals relayed to a channel.
+// A nil set means all signals.
+type subscription map[os.Signal]bool
+
+func (s subscription) has(sig os.Signal) bool {
+	return s == nil || s[sig]
+}
+
+// NewFakeSource returns a FakeSource and installs it as the source of signals
+// used by package signals until the end of the test.
+func NewFakeSource(tb testing.TB) *FakeSource {
+	s := &FakeSource{}
+	s.init()
+	tb.Cleanup(source.SetSynthetic(s))
+	return s
+}
+
+// NewOSSource returns an OSSource and installs it as the source of signals
+// used by package signals until the end of the test.
+func NewOSSource(tb testing.TB) *OSSource {
+	s := &OSSource{}
+	s.init()
+	tb.Cleanup(source.Set(s))
+	return s
+}
+
+// Notify implements the source interface of package signals, with the semantics of signal.Notify.
+// The subscription is recorded after the registration with package os/signal is in effect.
+func (s *OSSource) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	signal.Notify(c, sig...)
+	s.registry.Notify(c, sig...)
+}
+
+// Stop implements the source interface of package signals, with the semantics of signal.Stop.
+func (s *OSSource) Stop(c chan<- os.Signal) {
+	s.registry.Stop(c)
+	signal.Stop(c)
+}
+
+// Notify implements the source interface of package signals, with the semantics of signal.Notify.
+func (s *registry) Notify(c chan<- os.Signal, sig ...os.Signal) {
+	if c == nil {
+		panic("signalstest: Notify using nil channel")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	sub, ok := s.subs[c]
+	switch {
+	case len(sig) == 0:
+		sub = nil
+	case !ok:
+		sub = make(subscription)
+		fallthrough
+	case sub != nil:
+		for _, v := range sig {
+			sub[v] = true
+		}
+	}
+	s.subs[c] = sub
+	s.notify()
+}
+
+// Stop implements the source interface of package signals, with the semantics of signal.Stop.
+func (s *registry) Stop(c chan<- os.Signal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.subs[c]; ok {
+		delete(s.subs, c)
+		s.notify()
+	}
+}
+
+func (s *registry) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// Send delivers sig to every channel subscribed to it and returns the number of channels it was delivered to.
+// Like package os/signal, Send does not block; a channel without room for sig misses it.
+// If a channel is subscribed to sig, Send records it in signals.Stats as received.
+func (s *FakeSource) Send(sig os.Signal) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	caught := false
+	for c, sub := range s.subs {
+		if !sub.has(sig) {
+			continue
+		}
+		caught = true
+		select {
+		case c <- sig:
+			n++
+		default:
+		}
+	}
+	if caught {
+		source.Record(sig)
+	}
+	return n
+}
+
+// Registrations returns the number of calls to Notify made so far.
+// Tests can use it to assert that no signal handler was registered at all,
+// which Subscribers cannot tell once the handler is unregistered.
+func (s *registry) Registrations() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+// Subscribers returns the number of channels currently subscribed to sig.
+func (s *registry) Subscribers(sig os.Signal) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, sub := range s.subs {
+		if sub.has(sig) {
+			n++
+		}
+	}
+	return n
+}
+
+// WaitSubscribed blocks until at least one channel is subscribed to sig or ctx is done,
+// as reported by signals.Ready. It returns the error of ctx if ctx is done first.
+//
+// Calling WaitSubscribed before sending a signal makes sure that the signal is not sent
+// before the code under test is ready to receive it.
+func (s *registry) WaitSubscribed(ctx context.Context, sig os.Signal) error {
+	select {
+	case <-source.Ready(sig):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WaitNotSubscribed blocks until no channel is subscribed to sig or ctx is done.
+// It returns the error of ctx if ctx is done first.
+func (s *registry) WaitNotSubscribed(ctx context.Context, sig os.Signal) error {
+	for {
+		s.mu.Lock()
+		changed := s.changed
+		s.mu.Unlock()
+		if s.Subscribers(sig) == 0 {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// AssertSubscribed reports an error to tb unless at least one channel is subscribed to sig.
+func (s *registry) AssertSubscribed(tb testing.TB, sig os.Signal) {
+	tb.Helper()
+	if s.Subscribers(sig) == 0 {
+		tb.Errorf("Expected a subscriber of %v, got none", sig)
+	}
+}
+
+// AssertNotSubscribed reports an error to tb if any channel is subscribed to sig.
+func (s *registry) AssertNotSubscribed(tb testing.TB, sig os.Signal) {
+	tb.Helper()
+	if n := s.Subscribers(sig); n != 0 {
+		tb.Errorf("Expected no subscriber of %v, got %d", sig, n)
+	}
+}
@@ -0,0 +1,65 @@
+//go:build unix
+
+package signalstest_test
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestFakeSource(t *testing.T) {
+	t.Run("Send", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		got := make(chan any, 1)
+		go func() { got <- signals.Wait(ctx, syscall.SIGTERM) }()
+
+		if err := src.WaitSubscribed(ctx, syscall.SIGTERM); err != nil {
+			t.Fatal(err)
+		}
+		if n := src.Send(syscall.SIGINT); n != 0 {
+			t.Errorf("Expected SIGINT not to be delivered, got %d", n)
+		}
+		if n := src.Send(syscall.SIGTERM); n != 1 {
+			t.Errorf("Expected SIGTERM to be delivered once, got %d", n)
+		}
+		if sig := <-got; sig != syscall.SIGTERM {
+			t.Errorf("Expected SIGTERM, got %v", sig)
+		}
+		src.AssertNotSubscribed(t, syscall.SIGTERM)
+	})
+
+	t.Run("All signals", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		got := make(chan any, 1)
+		go func() { got <- signals.Wait(ctx) }()
+
+		if err := src.WaitSubscribed(ctx, syscall.SIGUSR2); err != nil {
+			t.Fatal(err)
+		}
+		src.AssertSubscribed(t, syscall.SIGHUP)
+		src.Send(syscall.SIGUSR2)
+		if sig := <-got; sig != syscall.SIGUSR2 {
+			t.Errorf("Expected SIGUSR2, got %v", sig)
+		}
+	})
+
+	t.Run("WaitSubscribed canceled", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := src.WaitSubscribed(ctx, syscall.SIGTERM); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package signals
+
+import (
+	"os"
+	"time"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// Stat is the record of the receipts of a signal since the start of the
+// process, or since the last call to ResetStats.
+type Stat struct {
+	Count uint64    // the number of times the signal was received
+	First time.Time // the time it was first received
+	Last  time.Time // the time it was last received
+}
+
+// Stats returns the records of the signals received by the process, keyed by
+// signal. A signal is recorded each time it is received while a subscriber of
+// this package, such as Context, Wait or a Watcher, is subscribed to it, once
+// however many subscribers it is delivered to. Injected events are not
+// recorded. Package statsvar publishes Stats with package expvar.
+func Stats() map[os.Signal]Stat {
+	stats := source.Stats()
+	m := make(map[os.Signal]Stat, len(stats))
+	for sig, st := range stats {
+		m[sig] = Stat{Count: st.Count, First: st.First, Last: st.Last}
+	}
+	return m
+}
+
+// ResetStats clears the records returned by Stats.
+func ResetStats() {
+	source.ResetStats()
+}
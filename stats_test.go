@@ -0,0 +1,99 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("FakeSource", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		src := signalstest.NewFakeSource(t)
+		signals.ResetStats()
+		a := signals.Subscribe(2, syscall.SIGHUP)
+		defer a.Close()
+		b := signals.Subscribe(2, syscall.SIGHUP)
+		defer b.Close()
+
+		first := clk.Now()
+		src.Send(syscall.SIGHUP)
+		clk.Advance(time.Minute)
+		src.Send(syscall.SIGHUP)
+		src.Send(syscall.SIGUSR1)
+
+		stats := signals.Stats()
+		if len(stats) != 1 {
+			t.Errorf("Expected 1 signal, got %v", stats)
+		}
+		st := stats[syscall.SIGHUP]
+		if st.Count != 2 {
+			t.Errorf("Expected 2, got %d", st.Count)
+		}
+		if !st.First.Equal(first) || !st.Last.Equal(first.Add(time.Minute)) {
+			t.Errorf("Expected %v and %v, got %v and %v", first, first.Add(time.Minute), st.First, st.Last)
+		}
+
+		signals.ResetStats()
+		if stats := signals.Stats(); len(stats) != 0 {
+			t.Errorf("Expected no signal, got %v", stats)
+		}
+	})
+
+	t.Run("OSSource", func(t *testing.T) {
+		src := signalstest.NewOSSource(t)
+		signals.ResetStats()
+		a := signals.Subscribe(1, syscall.SIGUSR2)
+		defer a.Close()
+		b := signals.Subscribe(1, syscall.SIGUSR2)
+		defer b.Close()
+		src.AssertSubscribed(t, syscall.SIGUSR2)
+
+		syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+		<-a.C
+		<-b.C
+		signalstest.Eventually(func() bool { return signals.Stats()[syscall.SIGUSR2].Count != 0 })
+		if n := signals.Stats()[syscall.SIGUSR2].Count; n != 1 {
+			t.Errorf("Expected 1, got %d", n)
+		}
+	})
+
+	t.Run("Overlapping subscriptions", func(t *testing.T) {
+		src := signalstest.NewOSSource(t)
+		signals.ResetStats()
+		a := signals.Subscribe(1, syscall.SIGUSR2, syscall.SIGWINCH)
+		b := signals.Subscribe(1, syscall.SIGUSR2)
+		defer b.Close()
+		a.Close()
+		src.AssertSubscribed(t, syscall.SIGUSR2)
+
+		syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+		<-b.C
+		signalstest.Eventually(func() bool { return signals.Stats()[syscall.SIGUSR2].Count != 0 })
+		if n := signals.Stats()[syscall.SIGUSR2].Count; n != 1 {
+			t.Errorf("Expected 1, got %d", n)
+		}
+	})
+
+	t.Run("Registration allocations", func(t *testing.T) {
+		held := signals.Subscribe(1, syscall.SIGUSR1, syscall.SIGUSR2)
+		defer held.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// The counter is already subscribed to both signals, so registering
+		// them again only updates its counts.
+		allocs := testing.AllocsPerRun(100, func() {
+			signals.Wait(readyCtx{ctx}, syscall.SIGUSR1, syscall.SIGUSR2)
+		})
+		if allocs > 5 {
+			t.Errorf("Expected at most 5 allocations, got %v", allocs)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+// Package statsvar publishes signals.Stats with package expvar.
+//
+// Importing the package, typically for its side effect alone,
+//
+//	import _ "github.com/goaux/signals/statsvar"
+//
+// publishes the variable "signals", served with the other variables of
+// package expvar on /debug/vars, for example:
+//
+//	"signals": {"hangup": {"count": 3, "first": "2024-05-01T09:00:00Z", "last": "2024-05-01T12:00:00Z"}}
+package statsvar
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/goaux/signals"
+)
+
+// Name is the name of the published variable.
+const Name = "signals"
+
+func init() {
+	expvar.Publish(Name, expvar.Func(Value))
+}
+
+// Stat is the record of a signal in the published variable.
+type Stat struct {
+	Count uint64    `json:"count"`
+	First time.Time `json:"first"`
+	Last  time.Time `json:"last"`
+}
+
+// Value returns the value of the published variable: the records of
+// signals.Stats, keyed by the name of the signal.
+func Value() any {
+	stats := signals.Stats()
+	m := make(map[string]Stat, len(stats))
+	for sig, st := range stats {
+		m[sig.String()] = Stat{Count: st.Count, First: st.First, Last: st.Last}
+	}
+	return m
+}
@@ -0,0 +1,32 @@
+package statsvar_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+	"github.com/goaux/signals/statsvar"
+)
+
+func TestValue(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	signals.ResetStats()
+	sub := signals.Subscribe(1, signals.Hangup)
+	defer sub.Close()
+	src.Send(signals.Hangup)
+
+	v := expvar.Get(statsvar.Name)
+	if v == nil {
+		t.Fatalf("Expected %q to be published", statsvar.Name)
+	}
+	var got map[string]statsvar.Stat
+	if err := json.Unmarshal([]byte(v.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	st, ok := got[signals.Hangup.String()]
+	if !ok || st.Count != 1 || st.First.IsZero() {
+		t.Errorf("Expected a record of 1 %v, got %v", signals.Hangup, got)
+	}
+}
@@ -0,0 +1,179 @@
+package signals
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// Storm describes an abnormal pattern of signals reported by a StormDetector.
+type Storm struct {
+	// Signal is the signal involved.
+	Signal os.Signal
+
+	// Count is the number of times Signal arrived within Window.
+	// It is zero if the storm was detected by failures.
+	Count int
+
+	// Window is the configured observation window.
+	Window time.Duration
+
+	// Failures is the number of consecutive failed handlings of Signal.
+	// It is zero if the storm was detected by arrival rate.
+	Failures int
+
+	// Err is the last failure reported by Result, if any.
+	Err error
+
+	// First and Last are the times of the first and the last event
+	// that contributed to the storm.
+	First, Last time.Time
+}
+
+// StormDetector detects abnormal patterns of incoming signals,
+// such as a misbehaving supervisor or a log rotation loop,
+// and reports them to Alert.
+//
+// Two patterns are detected independently for each signal:
+// more than Limit arrivals within Window, and more than FailureLimit
+// consecutive failures reported with Result.
+// After a storm is reported, the corresponding history of the signal is cleared,
+// so that the same burst is reported only once.
+//
+// The zero value detects nothing. A StormDetector must not be copied after first use.
+type StormDetector struct {
+	// Limit is the maximum number of arrivals of the same signal allowed within Window.
+	// Zero disables rate detection.
+	Limit int
+
+	// Window is the duration over which arrivals are counted.
+	Window time.Duration
+
+	// FailureLimit is the maximum number of consecutive failures of the same signal allowed.
+	// Zero disables failure detection.
+	FailureLimit int
+
+	// Alert is called synchronously when a storm is detected.
+	Alert func(Storm)
+
+	mu     sync.Mutex
+	states map[os.Signal]*stormState
+}
+
+type stormState struct {
+	arrivals []time.Time
+	failures int
+	first    time.Time
+}
+
+func (d *StormDetector) state(sig os.Signal) *stormState {
+	if d.states == nil {
+		d.states = make(map[os.Signal]*stormState)
+	}
+	s, ok := d.states[sig]
+	if !ok {
+		s = &stormState{}
+		d.states[sig] = s
+	}
+	return s
+}
+
+// Observe records an arrival of sig.
+func (d *StormDetector) Observe(sig os.Signal) {
+	if d.Limit <= 0 {
+		return
+	}
+	now := clock.Now()
+
+	d.mu.Lock()
+	s := d.state(sig)
+	i := 0
+	for i < len(s.arrivals) && now.Sub(s.arrivals[i]) > d.Window {
+		i++
+	}
+	s.arrivals = append(s.arrivals[i:], now)
+	var storm *Storm
+	if len(s.arrivals) > d.Limit {
+		storm = &Storm{
+			Signal: sig,
+			Count:  len(s.arrivals),
+			Window: d.Window,
+			First:  s.arrivals[0],
+			Last:   now,
+		}
+		s.arrivals = nil
+	}
+	d.mu.Unlock()
+
+	if storm != nil {
+		d.alert(*storm)
+	}
+}
+
+// Result records the outcome of handling sig, for example of a reload triggered by SIGHUP.
+// A nil err resets the count of consecutive failures.
+func (d *StormDetector) Result(sig os.Signal, err error) {
+	if d.FailureLimit <= 0 {
+		return
+	}
+	now := clock.Now()
+
+	d.mu.Lock()
+	s := d.state(sig)
+	var storm *Storm
+	if err == nil {
+		s.failures = 0
+	} else {
+		if s.failures == 0 {
+			s.first = now
+		}
+		s.failures++
+		if s.failures > d.FailureLimit {
+			storm = &Storm{
+				Signal:   sig,
+				Window:   d.Window,
+				Failures: s.failures,
+				Err:      err,
+				First:    s.first,
+				Last:     now,
+			}
+			s.failures = 0
+		}
+	}
+	d.mu.Unlock()
+
+	if storm != nil {
+		d.alert(*storm)
+	}
+}
+
+func (d *StormDetector) alert(storm Storm) {
+	if d.Alert != nil {
+		d.Alert(storm)
+	}
+}
+
+// Watch observes the specified signals until ctx is done.
+//
+// If no signals are provided, all incoming signals will be observed.
+// Like any subscription, Watch catches the signals while it runs, so that they
+// no longer trigger their default behavior, such as terminating the process on
+// SIGINT or SIGTERM; acting on them is left to other subscribers. To observe
+// the signals of an existing handler instead, call Observe from it.
+func (d *StormDetector) Watch(ctx context.Context, signals ...os.Signal) {
+	ch := make(chan os.Signal, 16)
+	source.Notify(ch, signals...)
+	defer source.Stop(ch)
+	for {
+		select {
+		case sig := <-ch:
+			d.Observe(sig)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
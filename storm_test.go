@@ -0,0 +1,119 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestStormDetector(t *testing.T) {
+	t.Run("Rate", func(t *testing.T) {
+		var storms []signals.Storm
+		d := &signals.StormDetector{
+			Limit:  3,
+			Window: time.Minute,
+			Alert:  func(s signals.Storm) { storms = append(storms, s) },
+		}
+		for i := 0; i < 3; i++ {
+			d.Observe(syscall.SIGHUP)
+		}
+		if len(storms) != 0 {
+			t.Fatalf("Expected no storm, got %v", storms)
+		}
+		d.Observe(syscall.SIGHUP)
+		if len(storms) != 1 {
+			t.Fatalf("Expected 1 storm, got %v", storms)
+		}
+		if s := storms[0]; s.Signal != syscall.SIGHUP || s.Count != 4 {
+			t.Errorf("Expected 4 SIGHUP, got %v %d", s.Signal, s.Count)
+		}
+		d.Observe(syscall.SIGHUP)
+		if len(storms) != 1 {
+			t.Errorf("Expected history to be cleared, got %v", storms)
+		}
+	})
+
+	t.Run("Window", func(t *testing.T) {
+		c := signalstest.NewFakeClock(t)
+		var storms []signals.Storm
+		d := &signals.StormDetector{
+			Limit:  1,
+			Window: 10 * time.Millisecond,
+			Alert:  func(s signals.Storm) { storms = append(storms, s) },
+		}
+		d.Observe(syscall.SIGHUP)
+		c.Advance(20 * time.Millisecond)
+		d.Observe(syscall.SIGHUP)
+		if len(storms) != 0 {
+			t.Errorf("Expected no storm, got %v", storms)
+		}
+		c.Advance(time.Millisecond)
+		d.Observe(syscall.SIGHUP)
+		if len(storms) != 1 {
+			t.Errorf("Expected 1 storm, got %v", storms)
+		}
+	})
+
+	t.Run("Failures", func(t *testing.T) {
+		var storms []signals.Storm
+		d := &signals.StormDetector{
+			FailureLimit: 2,
+			Alert:        func(s signals.Storm) { storms = append(storms, s) },
+		}
+		errReload := errors.New("reload failed")
+		d.Result(syscall.SIGHUP, errReload)
+		d.Result(syscall.SIGHUP, nil)
+		d.Result(syscall.SIGHUP, errReload)
+		d.Result(syscall.SIGHUP, errReload)
+		if len(storms) != 0 {
+			t.Fatalf("Expected no storm, got %v", storms)
+		}
+		d.Result(syscall.SIGHUP, errReload)
+		if len(storms) != 1 {
+			t.Fatalf("Expected 1 storm, got %v", storms)
+		}
+		if s := storms[0]; s.Failures != 3 || s.Err != errReload {
+			t.Errorf("Expected 3 failures with %v, got %d %v", errReload, s.Failures, s.Err)
+		}
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		alerted := make(chan signals.Storm, 1)
+		d := &signals.StormDetector{
+			Limit:  1,
+			Window: time.Minute,
+			Alert: func(s signals.Storm) {
+				alerted <- s
+				cancel()
+			},
+		}
+
+		src := signalstest.NewOSSource(t)
+		go func() {
+			src.WaitSubscribed(ctx, syscall.SIGUSR1)
+			syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+			time.Sleep(10 * time.Millisecond)
+			syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+		}()
+
+		d.Watch(ctx, syscall.SIGUSR1)
+		select {
+		case s := <-alerted:
+			if s.Signal != syscall.SIGUSR1 {
+				t.Errorf("Expected SIGUSR1, got %v", s.Signal)
+			}
+		default:
+			t.Error("Expected a storm")
+		}
+	})
+}
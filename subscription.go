@@ -0,0 +1,42 @@
+package signals
+
+import (
+	"os"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// Subscription is a channel registered to receive signals, like a channel
+// given to signal.Notify, that can be unsubscribed at any time with Close.
+//
+// A Subscription starts no goroutine, so a library with a shorter lifetime
+// than the process can subscribe and unsubscribe without leaking anything.
+type Subscription struct {
+	// C delivers the signals. It is closed by Close.
+	C <-chan os.Signal
+
+	c    chan os.Signal
+	once sync.Once
+}
+
+// Subscribe returns a Subscription delivering the specified signals to a
+// channel of the given buffer size. If no signals are provided, all incoming
+// signals will be relayed. Like package os/signal, signals that do not fit
+// in the buffer are dropped; see Watcher for counting them.
+func Subscribe(size int, signals ...os.Signal) *Subscription {
+	c := make(chan os.Signal, size)
+	source.Notify(c, signals...)
+	return &Subscription{C: c, c: c}
+}
+
+// Close unregisters the subscription and closes C, so that a subscriber
+// ranging over C stops. Signals already buffered in C can still be received.
+// It is safe to call Close more than once, and from any goroutine.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		// No signal is sent to c once Stop returns, so it can be closed.
+		source.Stop(s.c)
+		close(s.c)
+	})
+}
@@ -0,0 +1,112 @@
+package signals_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+// checkLeaks fails the test if the goroutines started during the test
+// have not stopped by the time it ends.
+func checkLeaks(t *testing.T) {
+	n := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		if !signalstest.Eventually(func() bool { return runtime.NumGoroutine() <= n }) {
+			t.Errorf("Expected %d goroutines, got %d", n, runtime.NumGoroutine())
+		}
+	})
+}
+
+func TestSubscription(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	checkLeaks(t)
+	sub := signals.Subscribe(2, signals.Hangup)
+	src.AssertSubscribed(t, signals.Hangup)
+
+	received := make(chan int)
+	go func() {
+		n := 0
+		for range sub.C {
+			n++
+		}
+		received <- n
+	}()
+	src.Send(signals.Hangup)
+	sub.Close()
+	sub.Close()
+	src.AssertNotSubscribed(t, signals.Hangup)
+	if n := <-received; n > 1 {
+		t.Errorf("Expected at most 1 signal, got %d", n)
+	}
+	if n := src.Send(signals.Hangup); n != 0 {
+		t.Errorf("Expected no delivery after Close, got %d", n)
+	}
+}
+
+func TestNoLeaks(t *testing.T) {
+	t.Run("Subscription", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		checkLeaks(t)
+		for i := 0; i < 10; i++ {
+			signals.Subscribe(1, signals.Hangup).Close()
+		}
+		if n := src.Subscribers(signals.Hangup); n != 0 {
+			t.Errorf("Expected no subscriber, got %d", n)
+		}
+	})
+
+	t.Run("Watcher", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		checkLeaks(t)
+		for i := 0; i < 10; i++ {
+			signals.NewWatcher(1, signals.Hangup).Stop()
+		}
+		if n := src.Subscribers(signals.Hangup); n != 0 {
+			t.Errorf("Expected no subscriber, got %d", n)
+		}
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		checkLeaks(t)
+		for i := 0; i < 10; i++ {
+			_, stop := signals.Context(context.Background(), signals.Hangup)
+			stop()
+		}
+		src.AssertNotSubscribed(t, signals.Hangup)
+	})
+
+	t.Run("Wait", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		checkLeaks(t)
+		for i := 0; i < 10; i++ {
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				signals.Wait(ctx, signals.Hangup)
+			}()
+			cancel()
+			<-done
+		}
+		src.AssertNotSubscribed(t, signals.Hangup)
+	})
+}
+
+func TestSourceSwap(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	sub := signals.Subscribe(1, signals.Hangup)
+	src.AssertSubscribed(t, signals.Hangup)
+
+	t.Run("Close", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		sub.Close()
+	})
+	src.AssertNotSubscribed(t, signals.Hangup)
+	if n := src.Send(signals.Hangup); n != 0 {
+		t.Errorf("Expected no delivery after Close, got %d", n)
+	}
+}
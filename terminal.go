@@ -0,0 +1,72 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// ErrNotTerminal is returned by RestoreTerminal for a file descriptor that is not a terminal.
+var ErrNotTerminal = errors.New("signals: not a terminal")
+
+// RestoreTerminal captures the state of the terminal fd, such as os.Stdin.Fd(),
+// and returns a copy of the parent context that restores this state when it is
+// done. Like Context, the returned context is canceled when SIGINT or SIGTERM is
+// received, when the returned stop function is called, or when the parent
+// context is done. This keeps a program that puts the terminal in raw mode from
+// leaving the shell of the user unusable.
+//
+// On SIGTSTP, the terminal is restored before the process is suspended, and the
+// state it had at the time is applied again once the process is continued.
+//
+// The stop function restores the terminal before returning. If the parent
+// context is done, the terminal is restored shortly after the returned context
+// is done; otherwise it is restored before. The terminal is restored only once,
+// so a program may change its state again after calling stop.
+//
+// RestoreTerminal returns an error wrapping ErrNotTerminal if fd is not a
+// terminal, or ErrUnsupported on platforms other than Linux, macOS and the BSDs.
+func RestoreTerminal(parent context.Context, fd int) (ctx context.Context, stop func(), err error) {
+	saved, err := getTermios(fd)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The terminal is restored once, so that the goroutine does not reset it
+	// after stop has returned, when the program may have changed it again.
+	var restored sync.Once
+	restore := func() { restored.Do(func() { setTermios(fd, saved) }) }
+
+	ctx, cancel := context.WithCancelCause(parent)
+	ch := make(chan os.Signal, 1)
+	source.Notify(ch, terminalSignals...)
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			source.Stop(ch)
+			cancel(nil)
+			restore()
+		})
+	}
+	go func() {
+		defer source.Stop(ch)
+		for {
+			select {
+			case sig := <-ch:
+				if sig == suspendSignal {
+					suspend(fd, saved)
+					continue
+				}
+				restore()
+				cancel(Canceled{Signal: sig})
+				return
+			case <-ctx.Done():
+				restore()
+				return
+			}
+		}
+	}()
+	return ctx, stop, nil
+}
@@ -0,0 +1,23 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package signals
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+var terminalSignals = []os.Signal{interrupt, terminate}
+
+var suspendSignal os.Signal
+
+type termios struct{}
+
+func getTermios(fd int) (*termios, error) {
+	return nil, fmt.Errorf("%w: terminal on %s/%s", ErrUnsupported, runtime.GOOS, runtime.GOARCH)
+}
+
+func setTermios(fd int, t *termios) error { return nil }
+
+func suspend(fd int, saved *termios) {}
@@ -0,0 +1,46 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package signals
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var terminalSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGTSTP}
+
+const suspendSignal = syscall.SIGTSTP
+
+func getTermios(fd int) (*syscall.Termios, error) {
+	var t syscall.Termios
+	if err := ioctlTermios(fd, ioctlGetTermios, &t); err != nil {
+		return nil, fmt.Errorf("%w: fd %d: %v", ErrNotTerminal, fd, err)
+	}
+	return &t, nil
+}
+
+func setTermios(fd int, t *syscall.Termios) error {
+	return ioctlTermios(fd, ioctlSetTermios, t)
+}
+
+func ioctlTermios(fd int, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// suspend restores the terminal state saved, stops the process as SIGTSTP
+// would by default, and applies the current terminal state again once the
+// process is continued.
+func suspend(fd int, saved *syscall.Termios) {
+	current, err := getTermios(fd)
+	setTermios(fd, saved)
+	syscall.Kill(syscall.Getpid(), syscall.SIGSTOP)
+	if err == nil {
+		setTermios(fd, current)
+	}
+}
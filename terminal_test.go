@@ -0,0 +1,136 @@
+//go:build linux
+
+package signals_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+	"unsafe"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func ioctl(fd uintptr, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// openTerminal opens the terminal side of a new pseudo-terminal.
+func openTerminal(t *testing.T) *os.File {
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR, 0)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { ptmx.Close() })
+	var unlock int32
+	if err := ioctl(ptmx.Fd(), syscall.TIOCSPTLCK, unsafe.Pointer(&unlock)); err != nil {
+		t.Fatal(err)
+	}
+	var n uint32
+	if err := ioctl(ptmx.Fd(), syscall.TIOCGPTN, unsafe.Pointer(&n)); err != nil {
+		t.Fatal(err)
+	}
+	tty, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { tty.Close() })
+	return tty
+}
+
+func echo(t *testing.T, tty *os.File) bool {
+	var termios syscall.Termios
+	if err := ioctl(tty.Fd(), syscall.TCGETS, unsafe.Pointer(&termios)); err != nil {
+		t.Fatal(err)
+	}
+	return termios.Lflag&syscall.ECHO != 0
+}
+
+func disableEcho(t *testing.T, tty *os.File) {
+	var termios syscall.Termios
+	if err := ioctl(tty.Fd(), syscall.TCGETS, unsafe.Pointer(&termios)); err != nil {
+		t.Fatal(err)
+	}
+	termios.Lflag &^= syscall.ECHO
+	if err := ioctl(tty.Fd(), syscall.TCSETS, unsafe.Pointer(&termios)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRestoreTerminal(t *testing.T) {
+	t.Run("signal", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		tty := openTerminal(t)
+		if !echo(t, tty) {
+			t.Fatal("Expected echo to be enabled")
+		}
+		ctx, stop, err := signals.RestoreTerminal(context.Background(), int(tty.Fd()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer stop()
+		disableEcho(t, tty)
+		src.AssertSubscribed(t, syscall.SIGTSTP)
+		src.Send(syscall.SIGINT)
+		signalstest.AssertCanceledBy(t, ctx, syscall.SIGINT)
+		if !echo(t, tty) {
+			t.Error("Expected the terminal to be restored")
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		tty := openTerminal(t)
+		ctx, stop, err := signals.RestoreTerminal(context.Background(), int(tty.Fd()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		disableEcho(t, tty)
+		stop()
+		if err := ctx.Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+		if !echo(t, tty) {
+			t.Error("Expected the terminal to be restored")
+		}
+	})
+
+	t.Run("raw mode after stop", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		tty := openTerminal(t)
+		n := runtime.NumGoroutine()
+		_, stop, err := signals.RestoreTerminal(context.Background(), int(tty.Fd()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		stop()
+		disableEcho(t, tty)
+		if !signalstest.Eventually(func() bool { return runtime.NumGoroutine() <= n }) {
+			t.Fatalf("Expected %d goroutines, got %d", n, runtime.NumGoroutine())
+		}
+		if echo(t, tty) {
+			t.Error("Expected the terminal not to be restored again")
+		}
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+		defer w.Close()
+		_, _, err = signals.RestoreTerminal(context.Background(), int(r.Fd()))
+		if !errors.Is(err, signals.ErrNotTerminal) {
+			t.Errorf("Expected ErrNotTerminal, got %v", err)
+		}
+	})
+}
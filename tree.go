@@ -0,0 +1,127 @@
+package signals
+
+import (
+	"context"
+	"sync"
+)
+
+// Tree coordinates the shutdown of a hierarchy of components.
+//
+// Each node of the tree owns a context that is canceled when the node is shut down.
+// Shutdown proceeds leaves-first: the context of a node is canceled only after
+// all of its children have been shut down and all goroutines started by them
+// with Go have returned. This lets, for example, HTTP handlers stop before the
+// database pool they depend on is closed.
+//
+// A Tree must be created with NewTree or Child.
+type Tree struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	children []*Tree
+	closed   bool
+
+	wg   sync.WaitGroup
+	once sync.Once
+	done chan struct{}
+}
+
+// NewTree returns the root of a new Tree.
+//
+// The tree is shut down when ctx is done or when Shutdown is called.
+// The contexts of the nodes carry the values of ctx, but they are not canceled
+// together with ctx; they are canceled one by one as the shutdown proceeds.
+func NewTree(ctx context.Context) *Tree {
+	t := newTree(ctx)
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.Shutdown()
+		case <-t.done:
+		}
+	}()
+	return t
+}
+
+func newTree(ctx context.Context) *Tree {
+	t := &Tree{done: make(chan struct{})}
+	t.ctx, t.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	return t
+}
+
+// Child registers and returns a new child node of t.
+//
+// If t has already started shutting down, the returned child is shut down immediately.
+func (t *Tree) Child() *Tree {
+	c := newTree(t.ctx)
+	t.mu.Lock()
+	closed := t.closed
+	if !closed {
+		t.children = append(t.children, c)
+	}
+	t.mu.Unlock()
+	if closed {
+		c.Shutdown()
+	}
+	return c
+}
+
+// Context returns the context of t.
+// It is canceled after all children of t have finished shutting down.
+func (t *Tree) Context() context.Context {
+	return t.ctx
+}
+
+// Go calls f in a new goroutine with the context of t, and reports whether it did.
+//
+// The shutdown of t is not complete until f returns; returning from f acknowledges
+// the cancellation of the context. Once the shutdown of t has started, Go refuses
+// to start f and returns false.
+func (t *Tree) Go(f func(ctx context.Context)) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		f(t.ctx)
+	}()
+	return true
+}
+
+// Shutdown shuts down t and its descendants and waits for completion.
+//
+// The children of t are shut down concurrently. Once all of them have completed,
+// the context of t is canceled and Shutdown waits for the goroutines started by Go to return.
+// Calling Shutdown more than once is safe; subsequent calls wait for the first one to complete.
+func (t *Tree) Shutdown() {
+	t.once.Do(func() {
+		t.mu.Lock()
+		t.closed = true
+		children := t.children
+		t.mu.Unlock()
+
+		var wg sync.WaitGroup
+		for _, c := range children {
+			wg.Add(1)
+			go func(c *Tree) {
+				defer wg.Done()
+				c.Shutdown()
+			}(c)
+		}
+		wg.Wait()
+
+		t.cancel()
+		t.wg.Wait()
+		close(t.done)
+	})
+	<-t.done
+}
+
+// Done returns a channel that is closed when the shutdown of t is complete.
+func (t *Tree) Done() <-chan struct{} {
+	return t.done
+}
@@ -0,0 +1,138 @@
+package signals_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+)
+
+func ExampleTree() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	root := signals.NewTree(ctx)
+	db := root.Child()
+	http := db.Child()
+
+	db.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		fmt.Println("db closed")
+	})
+	http.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		fmt.Println("http stopped")
+	})
+
+	cancel()
+	<-root.Done()
+	// Output:
+	// http stopped
+	// db closed
+}
+
+func TestTree(t *testing.T) {
+	t.Run("Leaves first", func(t *testing.T) {
+		root := signals.NewTree(context.Background())
+		var mu sync.Mutex
+		var order []string
+		record := func(name string) func(ctx context.Context) {
+			return func(ctx context.Context) {
+				<-ctx.Done()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+			}
+		}
+
+		a := root.Child()
+		a1 := a.Child()
+		a2 := a.Child()
+		root.Go(record("root"))
+		a.Go(record("a"))
+		a1.Go(record("a1"))
+		a2.Go(record("a2"))
+
+		root.Shutdown()
+
+		if len(order) != 4 {
+			t.Fatalf("Expected 4 entries, got %v", order)
+		}
+		if order[2] != "a" || order[3] != "root" {
+			t.Errorf("Expected [a1 a2 a root] in leaves-first order, got %v", order)
+		}
+	})
+
+	t.Run("Parent context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		root := signals.NewTree(ctx)
+		child := root.Child()
+
+		if err := child.Context().Err(); err != nil {
+			t.Fatalf("Expected nil, got %v", err)
+		}
+		cancel()
+
+		select {
+		case <-root.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the tree to be shut down")
+		}
+		if err := child.Context().Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Values are kept", func(t *testing.T) {
+		type key struct{}
+		ctx := context.WithValue(context.Background(), key{}, "value")
+		root := signals.NewTree(ctx)
+		defer root.Shutdown()
+
+		if v := root.Child().Context().Value(key{}); v != "value" {
+			t.Errorf("Expected value, got %v", v)
+		}
+	})
+
+	t.Run("Child after shutdown", func(t *testing.T) {
+		root := signals.NewTree(context.Background())
+		root.Shutdown()
+
+		child := root.Child()
+		select {
+		case <-child.Done():
+		default:
+			t.Error("Expected the child to be shut down")
+		}
+		if err := child.Context().Err(); err != context.Canceled {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Go during shutdown", func(t *testing.T) {
+		root := signals.NewTree(context.Background())
+		var running atomic.Int32
+		refused := make(chan struct{})
+		go func() {
+			defer close(refused)
+			for root.Go(func(ctx context.Context) {
+				running.Add(1)
+				<-ctx.Done()
+				running.Add(-1)
+			}) {
+			}
+		}()
+		root.Shutdown()
+		<-refused
+		if n := running.Load(); n != 0 {
+			t.Errorf("Expected no goroutine running after the shutdown, got %d", n)
+		}
+		if root.Go(func(context.Context) { t.Error("Expected f not to be called") }) {
+			t.Error("Expected Go to be refused after the shutdown")
+		}
+	})
+}
@@ -4,7 +4,9 @@ package signals
 import (
 	"context"
 	"os"
-	"os/signal"
+	"sync"
+
+	"github.com/goaux/signals/internal/source"
 )
 
 // Wait waits for the specified OS signals or context cancellation.
@@ -15,10 +17,31 @@ import (
 //
 // Multiple calls to Wait with the same signals are allowed and will work correctly:
 // each call will receive copies of incoming signals independently.
+//
+// If the context is already canceled, Wait returns nil immediately without
+// registering anything and without allocating, so it is cheap to call in a loop.
+//
+// On Plan 9, signals are notes (see syscall.Note); the portable names
+// Interrupt, Terminate, Hangup and Alarm map to the corresponding notes.
 func Wait(ctx context.Context, signals ...os.Signal) os.Signal {
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch, signals...)
-	defer signal.Stop(ch)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return wait(ctx, append([]os.Signal(nil), signals...))
+}
+
+func wait(ctx context.Context, signals []os.Signal) os.Signal {
+	ch := waitChans.Get().(chan os.Signal)
+	source.Notify(ch, signals...)
+	defer func() {
+		source.Stop(ch)
+		// A signal may have arrived together with the cancellation.
+		select {
+		case <-ch:
+		default:
+		}
+		waitChans.Put(ch)
+	}()
 	select {
 	case sig := <-ch:
 		return sig
@@ -26,3 +49,28 @@ func Wait(ctx context.Context, signals ...os.Signal) os.Signal {
 		return nil
 	}
 }
+
+// WaitFor calls produce with a context created by Context, and returns what
+// produce returns, unless one of the specified signals is received meanwhile.
+// Then the context is canceled and, once produce returns, WaitFor returns the
+// zero value of T and the Canceled holding the signal, so that callers get
+// a typed result or an error telling which signal interrupted it.
+//
+// If no signals are provided, all incoming signals will be relayed.
+func WaitFor[T any](ctx context.Context, produce func(ctx context.Context) (T, error), signals ...os.Signal) (T, error) {
+	ctx, stop := Context(ctx, signals...)
+	defer stop()
+	v, err := produce(ctx)
+	if c, ok := AsCanceled(context.Cause(ctx)); ok {
+		var zero T
+		return zero, c
+	}
+	return v, err
+}
+
+// waitChans pools the channels used by Wait, which saves the allocation of a
+// channel and its buffer on each registration; see BenchmarkWait.
+// A channel is put back only after it is unregistered and drained.
+var waitChans = sync.Pool{
+	New: func() any { return make(chan os.Signal, 1) },
+}
@@ -2,6 +2,7 @@ package signals_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"syscall"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
 )
 
 func Example() {
@@ -33,8 +35,9 @@ func TestWait(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
+		src := signalstest.NewOSSource(t)
 		go func() {
-			time.Sleep(100 * time.Millisecond)
+			src.WaitSubscribed(ctx, syscall.SIGINT)
 			syscall.Kill(os.Getpid(), syscall.SIGINT)
 		}()
 
@@ -61,8 +64,9 @@ func TestWait(t *testing.T) {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
+		src := signalstest.NewOSSource(t)
 		go func() {
-			time.Sleep(100 * time.Millisecond)
+			src.WaitSubscribed(ctx, syscall.SIGTERM)
 			syscall.Kill(os.Getpid(), syscall.SIGTERM)
 		}()
 
@@ -82,3 +86,99 @@ func TestWait(t *testing.T) {
 		}
 	})
 }
+
+func TestWaitAllocs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	allocs := testing.AllocsPerRun(100, func() {
+		signals.Wait(ctx, syscall.SIGINT, syscall.SIGTERM)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocation, got %v", allocs)
+	}
+}
+
+// readyCtx is a context that reports no error but whose Done channel is closed,
+// so that Wait goes through the registration without blocking.
+type readyCtx struct{ context.Context }
+
+func (readyCtx) Err() error { return nil }
+
+func BenchmarkWait(b *testing.B) {
+	b.Run("Canceled", func(b *testing.B) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			signals.Wait(ctx, syscall.SIGINT, syscall.SIGTERM)
+		}
+	})
+
+	b.Run("Registration", func(b *testing.B) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			signals.Wait(readyCtx{ctx}, syscall.SIGINT, syscall.SIGTERM)
+		}
+	})
+
+	// With the signals already subscribed to elsewhere, as by the context of
+	// a service, the cost is that of Wait alone.
+	b.Run("Subscribed", func(b *testing.B) {
+		held := signals.Subscribe(1, syscall.SIGINT, syscall.SIGTERM)
+		defer held.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			signals.Wait(readyCtx{ctx}, syscall.SIGINT, syscall.SIGTERM)
+		}
+	})
+}
+
+func TestWaitFor(t *testing.T) {
+	t.Run("Result", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		v, err := signals.WaitFor(context.Background(), func(ctx context.Context) (int, error) {
+			return 42, nil
+		}, syscall.SIGINT)
+		if v != 42 || err != nil {
+			t.Errorf("Expected 42 and nil, got %v and %v", v, err)
+		}
+	})
+
+	t.Run("Signal received", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		v, err := signals.WaitFor(context.Background(), func(ctx context.Context) (string, error) {
+			if err := src.WaitSubscribed(ctx, syscall.SIGINT); err != nil {
+				return "", err
+			}
+			src.Send(syscall.SIGINT)
+			<-ctx.Done()
+			return "partial", ctx.Err()
+		}, syscall.SIGINT)
+		if v != "" {
+			t.Errorf("Expected the zero value, got %q", v)
+		}
+		var c signals.Canceled
+		if !errors.As(err, &c) || c.Signal != syscall.SIGINT {
+			t.Errorf("Expected %v, got %v", signals.Canceled{Signal: syscall.SIGINT}, err)
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("Parent canceled", func(t *testing.T) {
+		signalstest.NewFakeSource(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		v, err := signals.WaitFor(ctx, func(ctx context.Context) (int, error) {
+			return 1, ctx.Err()
+		}, syscall.SIGINT)
+		if v != 1 || err != context.Canceled {
+			t.Errorf("Expected 1 and context.Canceled, got %v and %v", v, err)
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package signals
+
+import (
+	"context"
+	"time"
+
+	"github.com/goaux/signals/internal/clock"
+	"github.com/goaux/signals/internal/source"
+)
+
+// Watchdog requires the application to call the returned kick function at
+// least once per interval, until ctx is done. If kick is not called in time,
+// such as when the main loop is deadlocked, Watchdog delivers Terminate to
+// the subscribers of this package, as if the process had received it, and
+// stops watching.
+//
+// The application thus goes through its usual graceful shutdown path: the
+// contexts of Context monitoring Terminate, or all signals, are canceled, and
+// the Escalation given with WithEscalation, such as DumpGoroutines and Exit,
+// proceeds if the shutdown does not complete. No OS signal is sent.
+func Watchdog(ctx context.Context, interval time.Duration) (kick func()) {
+	kicked := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		timer := clock.NewTimer(interval)
+		defer func() { timer.Stop() }()
+		for {
+			select {
+			case <-kicked:
+				timer.Stop()
+				timer = clock.NewTimer(interval)
+			case <-timer.C():
+				source.Inject(terminate, true)
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		select {
+		case kicked <- struct{}{}:
+		case <-done:
+		}
+	}
+}
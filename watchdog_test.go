@@ -0,0 +1,41 @@
+package signals_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func TestWatchdog(t *testing.T) {
+	t.Run("kicked", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), signals.Terminate)
+		defer stop()
+		wctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		kick := signals.Watchdog(wctx, time.Second)
+		for i := 0; i < 3; i++ {
+			clk.BlockUntil(1)
+			clk.Advance(time.Second / 2)
+			kick()
+		}
+		if err := ctx.Err(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		signalstest.NewFakeSource(t)
+		ctx, stop := signals.Context(context.Background(), signals.Terminate)
+		defer stop()
+		signals.Watchdog(context.Background(), time.Second)
+		clk.BlockUntil(1)
+		clk.Advance(time.Second)
+		signalstest.AssertCanceledBy(t, ctx, signals.Terminate)
+	})
+}
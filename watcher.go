@@ -0,0 +1,190 @@
+package signals
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goaux/signals/internal/source"
+)
+
+// Watcher relays signals to a buffered channel, counting the signals that
+// were received and the ones that were dropped because the buffer was full.
+//
+// Package os/signal silently drops signals that do not fit in the channel of
+// a subscription. A Watcher makes these losses visible, so that, for example,
+// a reload storm can be detected and the buffer size tuned.
+//
+// A Watcher created by NewOrderedWatcher drops nothing instead: the signals
+// are queued without bound until they are received from C.
+type Watcher struct {
+	// C delivers the signals. It is closed by Stop.
+	C <-chan os.Signal
+
+	c        chan os.Signal
+	in       chan os.Signal
+	done     chan struct{}
+	stopped  chan struct{}
+	once     sync.Once
+	received atomic.Uint64
+	dropped  atomic.Uint64
+
+	ordered bool
+	queued  atomic.Int64 // signals queued by an ordered Watcher, not yet in C
+}
+
+// NewWatcher returns a Watcher relaying the specified signals to a channel of
+// the given buffer size. If no signals are provided, all incoming signals will
+// be relayed. It panics if size is negative.
+func NewWatcher(size int, signals ...os.Signal) *Watcher {
+	c := make(chan os.Signal, size)
+	w := &Watcher{
+		C:       c,
+		c:       c,
+		in:      make(chan os.Signal, 64),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	source.Notify(w.in, signals...)
+	go w.relay()
+	return w
+}
+
+// NewOrderedWatcher returns a Watcher relaying the specified signals to C in
+// the order they are delivered by the runtime, queueing them without bound
+// until they are received, so that none is dropped after delivery. If no
+// signals are provided, all incoming signals will be relayed.
+//
+// This suits control protocols built on signals, such as realtime signals
+// carrying commands, at the cost of memory. Note that the Go runtime itself
+// keeps a single pending flag per signal: occurrences of a signal arriving
+// before the runtime relays the previous one are coalesced, and signals
+// arriving together are relayed in an unspecified order. A sender that needs
+// every occurrence must wait for each to be received, for example with an
+// acknowledgement of its own.
+//
+// Unlike NewWatcher, the queue is flushed to C after Stop, so C must be
+// received from until it is closed.
+func NewOrderedWatcher(signals ...os.Signal) *Watcher {
+	c := make(chan os.Signal)
+	w := &Watcher{
+		C:       c,
+		c:       c,
+		in:      make(chan os.Signal, 64),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+		ordered: true,
+	}
+	source.Notify(w.in, signals...)
+	go w.relayOrdered()
+	return w
+}
+
+func (w *Watcher) relay() {
+	defer close(w.stopped)
+	for {
+		select {
+		case sig := <-w.in:
+			w.forward(sig)
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// relayOrdered is the relay of an ordered Watcher. Once done is closed, it
+// takes the signals left in in, flushes the queue to c and closes it.
+func (w *Watcher) relayOrdered() {
+	var queue []os.Signal
+	push := func(sig os.Signal) {
+		w.received.Add(1)
+		w.queued.Add(1)
+		queue = append(queue, sig)
+	}
+	done := w.done
+	for done != nil || len(queue) > 0 {
+		var (
+			out  chan os.Signal
+			next os.Signal
+		)
+		if len(queue) > 0 {
+			out, next = w.c, queue[0]
+		}
+		select {
+		case sig := <-w.in:
+			push(sig)
+		case out <- next:
+			w.queued.Add(-1)
+			queue[0] = nil
+			queue = queue[1:]
+		case <-done:
+			close(w.stopped)
+			done = nil
+			// done is closed after source.Stop, so no signal is sent to in any more.
+		drain:
+			for {
+				select {
+				case sig := <-w.in:
+					push(sig)
+				default:
+					break drain
+				}
+			}
+		}
+	}
+	close(w.c)
+}
+
+func (w *Watcher) forward(sig os.Signal) {
+	w.received.Add(1)
+	select {
+	case w.c <- sig:
+	default:
+		w.dropped.Add(1)
+	}
+}
+
+// Received returns the number of signals received so far, including the dropped ones.
+func (w *Watcher) Received() uint64 {
+	return w.received.Load()
+}
+
+// Dropped returns the number of signals dropped so far because C was full.
+//
+// Signals arriving faster than the Watcher can relay them may also be
+// dropped by the runtime before being counted.
+func (w *Watcher) Dropped() uint64 {
+	return w.dropped.Load()
+}
+
+// Pending returns the number of signals waiting in C to be consumed,
+// including the ones queued by an ordered Watcher.
+func (w *Watcher) Pending() int {
+	return len(w.c) + int(w.queued.Load())
+}
+
+// Stop unregisters the signals and closes C once the signals already received
+// are relayed. It is safe to call Stop more than once.
+//
+// For an ordered Watcher, Stop returns without waiting for the queued signals
+// to be received; C is closed after the last of them.
+func (w *Watcher) Stop() {
+	w.once.Do(func() {
+		source.Stop(w.in)
+		close(w.done)
+		<-w.stopped
+		if w.ordered {
+			return
+		}
+	drain:
+		for {
+			select {
+			case sig := <-w.in:
+				w.forward(sig)
+			default:
+				break drain
+			}
+		}
+		close(w.c)
+	})
+}
@@ -0,0 +1,141 @@
+//go:build unix
+
+package signals_test
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+)
+
+func ExampleWatcher() {
+	w := signals.NewWatcher(4, syscall.SIGHUP)
+	defer w.Stop()
+	go func() {
+		for range w.C {
+			// reload
+		}
+	}()
+	// ...
+	fmt.Println(w.Dropped())
+	// Output: 0
+}
+
+func TestWatcher(t *testing.T) {
+	src := signalstest.NewFakeSource(t)
+	w := signals.NewWatcher(2, syscall.SIGHUP)
+	src.AssertSubscribed(t, syscall.SIGHUP)
+
+	for i := 0; i < 5; i++ {
+		src.Send(syscall.SIGHUP)
+	}
+	if !signalstest.Eventually(func() bool { return w.Received() == 5 }) {
+		t.Fatalf("Expected 5 received, got %d", w.Received())
+	}
+	if n := w.Dropped(); n != 3 {
+		t.Errorf("Expected 3 dropped, got %d", n)
+	}
+	if n := w.Pending(); n != 2 {
+		t.Errorf("Expected 2 pending, got %d", n)
+	}
+	<-w.C
+	if n := w.Pending(); n != 1 {
+		t.Errorf("Expected 1 pending, got %d", n)
+	}
+
+	w.Stop()
+	w.Stop()
+	src.AssertNotSubscribed(t, syscall.SIGHUP)
+	n := 0
+	for range w.C {
+		n++
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 signal left, got %d", n)
+	}
+}
+
+func TestOrderedWatcher(t *testing.T) {
+	t.Run("FakeSource", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		w := signals.NewOrderedWatcher(syscall.SIGUSR1, syscall.SIGUSR2)
+		src.AssertSubscribed(t, syscall.SIGUSR1)
+
+		sent := hammer(t, w, func(sig os.Signal) { src.Send(sig) }, []os.Signal{syscall.SIGUSR1, syscall.SIGUSR2}, 500)
+		if n := w.Pending(); n != len(sent) {
+			t.Errorf("Expected %d pending, got %d", len(sent), n)
+		}
+		w.Stop()
+		src.AssertNotSubscribed(t, syscall.SIGUSR1)
+		assertOrder(t, w, sent)
+	})
+
+	t.Run("Realtime signals", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("realtime signals are specific to Linux")
+		}
+		src := signalstest.NewOSSource(t)
+		// SIGRTMIN+2 onwards, since glibc reserves the first two.
+		var sigs []os.Signal
+		for s := syscall.Signal(36); s < 44; s++ {
+			sigs = append(sigs, s)
+		}
+		w := signals.NewOrderedWatcher(sigs...)
+		src.AssertSubscribed(t, sigs[0])
+
+		pid := os.Getpid()
+		sent := hammer(t, w, func(sig os.Signal) {
+			syscall.Kill(pid, sig.(syscall.Signal))
+		}, sigs, 300)
+		w.Stop()
+		assertOrder(t, w, sent)
+	})
+}
+
+// hammer sends n signals to w, cycling through sigs, each as soon as the
+// previous one is received by w, without consuming C. It returns the signals
+// sent, in order.
+func hammer(t *testing.T, w *signals.Watcher, send func(os.Signal), sigs []os.Signal, n int) []os.Signal {
+	t.Helper()
+	deadline := time.Now().Add(signalstest.AssertTimeout)
+	sent := make([]os.Signal, 0, n)
+	for i := 0; i < n; i++ {
+		sig := sigs[i%len(sigs)]
+		send(sig)
+		sent = append(sent, sig)
+		for w.Received() != uint64(i+1) {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected %d received, got %d", i+1, w.Received())
+			}
+			runtime.Gosched()
+		}
+	}
+	return sent
+}
+
+// assertOrder receives from w until C is closed, and checks that the signals
+// are the ones sent, in order.
+func assertOrder(t *testing.T, w *signals.Watcher, sent []os.Signal) {
+	t.Helper()
+	var got []os.Signal
+	for sig := range w.C {
+		got = append(got, sig)
+	}
+	if len(got) != len(sent) {
+		t.Fatalf("Expected %d signals, got %d", len(sent), len(got))
+	}
+	for i := range sent {
+		if got[i] != sent[i] {
+			t.Fatalf("Expected %v at %d, got %v", sent[i], i, got[i])
+		}
+	}
+	if n := w.Dropped(); n != 0 {
+		t.Errorf("Expected 0 dropped, got %d", n)
+	}
+}
@@ -0,0 +1,198 @@
+// Package webhook notifies external systems of signal-driven shutdowns,
+// for fleet-level shutdown auditing.
+//
+// A Notifier posts a small JSON payload to the configured URLs when a shutdown
+// starts and when it completes:
+//
+//	var n = &webhook.Notifier{URLs: []string{"https://audit.example.com/shutdown"}}
+//
+//	report, err := signals.Run(ctx, func(ctx context.Context) error {
+//		n.Watch(ctx)
+//		return serve(ctx)
+//	}, syscall.SIGTERM)
+//	n.Completed(report)
+//	n.Wait()
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/internal/clock"
+)
+
+// Events of a Payload.
+const (
+	EventStarted   = "shutdown_started"
+	EventCompleted = "shutdown_completed"
+)
+
+// Payload is the JSON body posted to the webhooks.
+type Payload struct {
+	Event    string    `json:"event"`
+	Signal   string    `json:"signal,omitempty"`
+	Host     string    `json:"host"`
+	PID      int       `json:"pid"`
+	Time     time.Time `json:"time"`
+	Received time.Time `json:"received"`
+	Duration string    `json:"duration,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// DefaultDeadline is the Deadline used when Notifier.Deadline is zero.
+const DefaultDeadline = 5 * time.Second
+
+// Notifier posts shutdown notifications to webhooks.
+//
+// Notifications are sent in the background; the methods of Notifier never
+// block on the network, except Wait.
+type Notifier struct {
+	// URLs are the webhook URLs the payload is posted to.
+	URLs []string
+
+	// Client is the HTTP client used. If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// Retries is the number of additional attempts after a failed post.
+	Retries int
+
+	// Deadline bounds the time spent on a notification to a URL, retries included.
+	// If zero, DefaultDeadline is used.
+	Deadline time.Duration
+
+	// OnError, if not nil, is called with the error of each failed notification.
+	OnError func(error)
+
+	wg sync.WaitGroup
+}
+
+// Watch arranges to notify the start of a shutdown when ctx is canceled by a signal,
+// as reported by signals.FromContext.
+func (n *Notifier) Watch(ctx context.Context) {
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		<-ctx.Done()
+		sig, ok := signals.FromContext(ctx)
+		if !ok {
+			return
+		}
+		p := n.payload(EventStarted)
+		p.Signal = sig.String()
+		p.Received, _ = signals.ReceivedAt(ctx)
+		n.send(p)
+	}()
+}
+
+// Completed notifies the completion of a shutdown described by r.
+// Nothing is sent if r holds no signal, since the shutdown was not signal-driven.
+func (n *Notifier) Completed(r signals.Report) {
+	if r.Signal == nil {
+		return
+	}
+	p := n.payload(EventCompleted)
+	p.Signal = r.Signal.String()
+	p.Received = r.Received
+	p.Duration = r.Shutdown().String()
+	if r.Err != nil {
+		p.Error = r.Err.Error()
+	}
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		n.send(p)
+	}()
+}
+
+// Wait waits for the notifications in progress to complete.
+// It is bounded by the Deadline of the notifications, once the contexts passed to Watch are done.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+func (n *Notifier) payload(event string) Payload {
+	host, _ := os.Hostname()
+	return Payload{
+		Event: event,
+		Host:  host,
+		PID:   os.Getpid(),
+		Time:  clock.Now(),
+	}
+}
+
+func (n *Notifier) send(p Payload) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		n.report(err)
+		return
+	}
+	var wg sync.WaitGroup
+	for _, url := range n.URLs {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			n.report(n.post(url, body))
+		}(url)
+	}
+	wg.Wait()
+}
+
+func (n *Notifier) post(url string, body []byte) error {
+	deadline := n.Deadline
+	if deadline == 0 {
+		deadline = DefaultDeadline
+	}
+	ctx, cancel := clock.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var err error
+	for attempt := 0; attempt <= n.Retries; attempt++ {
+		if attempt > 0 {
+			timer := clock.NewTimer(time.Duration(attempt) * 100 * time.Millisecond)
+			select {
+			case <-timer.C():
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("webhook: %s: %w", url, err)
+			}
+		}
+		if err = post(ctx, client, url, body); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("webhook: %s: %w", url, err)
+}
+
+func post(ctx context.Context, client *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (n *Notifier) report(err error) {
+	if err != nil && n.OnError != nil {
+		n.OnError(err)
+	}
+}
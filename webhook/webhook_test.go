@@ -0,0 +1,114 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goaux/signals"
+	"github.com/goaux/signals/signalstest"
+	"github.com/goaux/signals/webhook"
+)
+
+func TestNotifier(t *testing.T) {
+	t.Run("Started and completed", func(t *testing.T) {
+		src := signalstest.NewFakeSource(t)
+		clk := signalstest.NewFakeClock(t)
+		var mu sync.Mutex
+		var got []webhook.Payload
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var p webhook.Payload
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			got = append(got, p)
+			mu.Unlock()
+		}))
+		defer srv.Close()
+
+		n := &webhook.Notifier{URLs: []string{srv.URL}, OnError: func(err error) { t.Error(err) }}
+		received := clk.Now()
+		report, _ := signals.Run(context.Background(), func(ctx context.Context) error {
+			n.Watch(ctx)
+			src.Send(syscall.SIGTERM)
+			<-ctx.Done()
+			clk.Advance(time.Second)
+			return nil
+		}, syscall.SIGTERM)
+		n.Completed(report)
+		n.Wait()
+
+		if len(got) != 2 {
+			t.Fatalf("Expected 2 payloads, got %v", got)
+		}
+		events := map[string]webhook.Payload{}
+		for _, p := range got {
+			events[p.Event] = p
+		}
+		started, completed := events[webhook.EventStarted], events[webhook.EventCompleted]
+		if started.Signal != "terminated" || started.PID != os.Getpid() || !started.Received.Equal(received) {
+			t.Errorf("Unexpected payload %+v", started)
+		}
+		if completed.Signal != "terminated" || completed.Duration != "1s" || !completed.Received.Equal(received) || !completed.Time.Equal(received.Add(time.Second)) {
+			t.Errorf("Unexpected payload %+v", completed)
+		}
+	})
+
+	t.Run("Retries", func(t *testing.T) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer srv.Close()
+
+		n := &webhook.Notifier{URLs: []string{srv.URL}, Retries: 2, OnError: func(err error) { t.Error(err) }}
+		n.Completed(signals.Report{Signal: syscall.SIGTERM})
+		n.Wait()
+		if c := calls.Load(); c != 3 {
+			t.Errorf("Expected 3 calls, got %d", c)
+		}
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		clk := signalstest.NewFakeClock(t)
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer srv.Close()
+		defer close(release)
+
+		var failed atomic.Bool
+		n := &webhook.Notifier{
+			URLs:     []string{srv.URL},
+			Deadline: time.Minute,
+			OnError:  func(error) { failed.Store(true) },
+		}
+		n.Completed(signals.Report{Signal: syscall.SIGTERM})
+		clk.BlockUntil(1)
+		clk.Advance(time.Minute)
+		n.Wait()
+		if !failed.Load() {
+			t.Error("Expected an error")
+		}
+	})
+
+	t.Run("No signal", func(t *testing.T) {
+		n := &webhook.Notifier{URLs: []string{"http://invalid.invalid"}, OnError: func(err error) { t.Error(err) }}
+		ctx, cancel := context.WithCancel(context.Background())
+		n.Watch(ctx)
+		cancel()
+		n.Completed(signals.Report{})
+		n.Wait()
+	})
+}